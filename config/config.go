@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -9,13 +10,18 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App   `yaml:"app"`
-		HTTP  `yaml:"http"`
-		Log   `yaml:"logger"`
-		PG    `yaml:"postgres"`
-		JWT   `yaml:"jwt"`
-		Redis `yaml:"redis"`
-		Gmail `yaml:"gmail"`
+		App        `yaml:"app"`
+		HTTP       `yaml:"http"`
+		Log        `yaml:"logger"`
+		PG         `yaml:"postgres"`
+		JWT        `yaml:"jwt"`
+		Redis      `yaml:"redis"`
+		RMQ        `yaml:"rabbitmq"`
+		Mailer     `yaml:"mailer"`
+		Storage    `yaml:"storage"`
+		Screening  `yaml:"screening"`
+		Moderation `yaml:"moderation"`
+		CheckIn    `yaml:"check_in"`
 	}
 
 	// App -.
@@ -42,7 +48,8 @@ type (
 
 	// JWT -.
 	JWT struct {
-		Secret string `env-required:"true" yaml:"secret" env:"JWT_SECRET"`
+		Secret         string        `env-required:"true" yaml:"secret" env:"JWT_SECRET"`
+		AccessTokenTTL time.Duration `yaml:"access_token_ttl" env:"JWT_ACCESS_TOKEN_TTL" env-default:"15m"`
 	}
 
 	// Redis -.
@@ -51,12 +58,64 @@ type (
 		RedisPort int    `env-required:"true" yaml:"port" env:"REDIS_PORT"`
 	}
 
-	// Gmail -.
-	Gmail struct {
-		Email     string `env-required:"true" yaml:"email" env:"EMAIL"`
-		EmailPass string `env-required:"true" yaml:"email_pass" env:"EMAIL_PASS"`
-		Host      string `env-required:"true" yaml:"host" env:"SMTP_HOST"`
-		Port      string `env-required:"true" yaml:"port" env:"SMTP_PORT"`
+	// RMQ -.
+	RMQ struct {
+		URL             string        `env:"RMQ_URL"`
+		EmailQueue      string        `yaml:"email_queue"       env:"RMQ_EMAIL_QUEUE"       env-default:"email"`
+		MaxAttempts     int           `yaml:"max_attempts"      env:"RMQ_MAX_ATTEMPTS"      env-default:"5"`
+		RetryBackoff    time.Duration `yaml:"retry_backoff"     env:"RMQ_RETRY_BACKOFF"     env-default:"2s"`
+		MaxRetryBackoff time.Duration `yaml:"max_retry_backoff" env:"RMQ_MAX_RETRY_BACKOFF" env-default:"5m"`
+	}
+
+	// Mailer -.
+	Mailer struct {
+		Driver        string `yaml:"driver"         env:"MAILER_DRIVER"         env-default:"smtp"`
+		DefaultLocale string `yaml:"default_locale" env:"MAILER_DEFAULT_LOCALE" env-default:"en"`
+		From          string `yaml:"from"           env:"MAILER_FROM"`
+		Email         string `yaml:"email"          env:"EMAIL"`
+		EmailPass     string `yaml:"email_pass"     env:"EMAIL_PASS"`
+		Host          string `yaml:"host"           env:"SMTP_HOST"`
+		Port          string `yaml:"port"           env:"SMTP_PORT"`
+	}
+
+	// Screening -.
+	Screening struct {
+		BlockedWords    []string      `yaml:"blocked_words"    env:"SCREENING_BLOCKED_WORDS"    env-separator:","`
+		FlaggedWords    []string      `yaml:"flagged_words"    env:"SCREENING_FLAGGED_WORDS"    env-separator:","`
+		HoldLinks       bool          `yaml:"hold_links"       env:"SCREENING_HOLD_LINKS"       env-default:"true"`
+		HoldPhones      bool          `yaml:"hold_phones"      env:"SCREENING_HOLD_PHONES"      env-default:"true"`
+		DuplicateWindow time.Duration `yaml:"duplicate_window" env:"SCREENING_DUPLICATE_WINDOW" env-default:"720h"`
+		RateWindow      time.Duration `yaml:"rate_window"      env:"SCREENING_RATE_WINDOW"      env-default:"1h"`
+		RateLimit       int           `yaml:"rate_limit"       env:"SCREENING_RATE_LIMIT"       env-default:"5"`
+	}
+
+	// Moderation -.
+	Moderation struct {
+		// ReportThreshold is the number of distinct users whose open reports send a published review back to moderation
+		ReportThreshold int `yaml:"report_threshold" env:"MODERATION_REPORT_THRESHOLD" env-default:"3"`
+	}
+
+	// CheckIn -.
+	CheckIn struct {
+		MaxDistance float64       `yaml:"max_distance" env:"CHECK_IN_MAX_DISTANCE" env-default:"500"` // meters
+		Cooldown    time.Duration `yaml:"cooldown"     env:"CHECK_IN_COOLDOWN"     env-default:"4h"`
+	}
+
+	// Storage -.
+	Storage struct {
+		Driver        string `yaml:"driver"          env:"STORAGE_DRIVER"          env-default:"local"`
+		BaseURL       string `yaml:"base_url"        env:"STORAGE_BASE_URL"        env-default:"/media"`
+		MaxUploadSize int64  `yaml:"max_upload_size" env:"STORAGE_MAX_UPLOAD_SIZE" env-default:"52428800"`
+		LocalDir      string `yaml:"local_dir"       env:"STORAGE_LOCAL_DIR"       env-default:"./uploads"`
+		S3Endpoint    string `yaml:"s3_endpoint"     env:"S3_ENDPOINT"`
+		S3Region      string `yaml:"s3_region"       env:"S3_REGION"`
+		S3Bucket      string `yaml:"s3_bucket"       env:"S3_BUCKET"`
+		S3AccessKey   string `env:"S3_ACCESS_KEY"`
+		S3SecretKey   string `env:"S3_SECRET_KEY"`
+		S3UseSSL      bool   `yaml:"s3_use_ssl"      env:"S3_USE_SSL"`
+		S3PublicURL   string `yaml:"s3_public_url"   env:"S3_PUBLIC_URL"` // a CDN or proxy in front of the bucket
+
+		MaxImagePixels int64 `yaml:"max_image_pixels" env:"STORAGE_MAX_IMAGE_PIXELS" env-default:"40000000"` // width×height
 	}
 )
 
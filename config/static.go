@@ -3,22 +3,106 @@ package config
 import "time"
 
 var (
-	ErrorInvalidRequest = "INVALID_REQUEST"
-	ErrorInvalidToken   = "INVALID_TOKEN"
-	ErrorInvalidUser    = "INVALID_USER"
-	ErrorInvalidPass    = "INVALID_PASS"
-	ErrorInvalidEmail   = "INVALID_EMAIL"
-	ErrorInvalidPhone   = "INVALID_PHONE"
-	ErrorSessionExpired = "SESSION_EXPIRED"
-	ErrorInternalServer = "INTERNAL_SERVER"
-	ErrorNotFound       = "NOT_FOUND"
-	ErrorUnauthorized   = "UNAUTHORIZED"
-	ErrorForbidden      = "FORBIDDEN"
-	ErrorConflict       = "CONFLICT"
-	ErrorBadRequest     = "BAD_REQUEST"
-	ErrorDuplicateKey   = "DUPLICATE_KEY"
+	ErrorInvalidRequest  = "INVALID_REQUEST"
+	ErrorInvalidToken    = "INVALID_TOKEN"
+	ErrorInvalidUser     = "INVALID_USER"
+	ErrorInvalidPass     = "INVALID_PASS"
+	ErrorInvalidEmail    = "INVALID_EMAIL"
+	ErrorInvalidPhone    = "INVALID_PHONE"
+	ErrorSessionExpired  = "SESSION_EXPIRED"
+	ErrorInternalServer  = "INTERNAL_SERVER"
+	ErrorNotFound        = "NOT_FOUND"
+	ErrorUnauthorized    = "UNAUTHORIZED"
+	ErrorForbidden       = "FORBIDDEN"
+	ErrorConflict        = "CONFLICT"
+	ErrorBadRequest      = "BAD_REQUEST"
+	ErrorDuplicateKey    = "DUPLICATE_KEY"
+	ErrorTooManyRequests = "TOO_MANY_REQUESTS"
+	ErrorContentRejected = "CONTENT_REJECTED"
 )
 
 var (
 	TokenExpireTime = 24 * time.Hour * 7 // 7 days
 )
+
+var (
+	OtpLength         = 6
+	OtpExpireTime     = 5 * time.Minute
+	OtpMaxAttempts    = 5
+	OtpLockoutTime    = 15 * time.Minute
+	OtpResendCooldown = time.Minute
+)
+
+var (
+	DefaultSearchRadiusKm = 5.0
+	MaxSearchRadiusKm     = 100.0
+)
+
+const (
+	ReviewStatusPending   = "pending"
+	ReviewStatusPublished = "published"
+	ReviewStatusHidden    = "hidden"
+	ReviewStatusRemoved   = "removed"
+)
+
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusApproved = "approved"
+	ClaimStatusRejected = "rejected"
+)
+
+const (
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum"
+
+	MinPriceLevel = 1
+	MaxPriceLevel = 4
+
+	// MaxCategoryAttributes bounds the attributes loaded for a category
+	MaxCategoryAttributes = 100
+
+	// MaxBusinessCategories bounds the categories a business is listed under
+	MaxBusinessCategories = 10
+)
+
+const (
+	SearchTypeBusiness = "business"
+	SearchTypeReview   = "review"
+
+	// MaxSearchQueryLength bounds the q parameter of the search endpoint
+	MaxSearchQueryLength = 200
+	SearchDefaultLimit   = 10
+	SearchMaxLimit       = 50
+
+	SuggestionTypeBusiness = "business"
+	SuggestionTypeCategory = "category"
+	SuggestionTypeQuery    = "query"
+)
+
+var (
+	SuggestLimit    = 5 // per suggestion type
+	SuggestTimeout  = 300 * time.Millisecond
+	SuggestCacheTTL = 5 * time.Minute
+	// SuggestMinUsers is how many distinct users must have run a search before it is suggested to others
+	SuggestMinUsers = 3
+	// SearchRecordLimit bounds the searches of a user recorded for suggestions per SearchRecordWindow
+	SearchRecordLimit  = 30
+	SearchRecordWindow = time.Hour
+)
+
+var (
+	DefaultCollectionName = "Bookmarks"
+)
+
+const (
+	FeedItemReview = "review"
+	FeedItemPhoto  = "photo"
+
+	// FeedOrder is the order feed cursors are signed for
+	FeedOrder = "created_at desc"
+)
+
+var (
+	FeedDefaultLimit = 20
+	FeedMaxLimit     = 100
+)
@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -26,6 +27,9 @@ const (
 	rpcServerExchange = "rpc_server"
 	rpcClientExchange = "rpc_client"
 	requests          = 10
+
+	// MailHog, the SMTP stand-in the app sends email to
+	mailhogAPI = "http://yelp-mailhog:8025/api/v2"
 )
 
 func TestMain(m *testing.M) {
@@ -137,3 +141,30 @@ func TestRMQClientRPC(t *testing.T) {
 		}
 	}
 }
+
+// HTTP POST: /auth/register sends the verification code by email.
+func TestHTTPRegisterSendsOtp(t *testing.T) {
+	email := fmt.Sprintf("integration-%d@example.com", time.Now().UnixNano())
+	body := fmt.Sprintf(`{
+		"full_name": "Integration Test",
+		"username": "integration%d",
+		"email": "%s",
+		"gender": "male",
+		"password": "secret123"
+	}`, time.Now().UnixNano(), email)
+
+	Test(t,
+		Description("Register Success"),
+		Post(basePath+"/auth/register"),
+		Send().Headers("Content-Type").Add("application/json"),
+		Send().Body().String(body),
+		Expect().Status().Equal(http.StatusCreated),
+	)
+
+	Test(t,
+		Description("Verification email sent"),
+		Get(mailhogAPI+"/search?kind=to&query="+email),
+		Expect().Status().Equal(http.StatusOK),
+		Expect().Body().JSON().JQ(".total").Equal(1),
+	)
+}
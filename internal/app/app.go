@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -14,8 +15,12 @@ import (
 	"github.com/abdulazizax/yelp/internal/usecase"
 	"github.com/abdulazizax/yelp/pkg/httpserver"
 	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/mailer"
 	"github.com/abdulazizax/yelp/pkg/postgres"
+	rmqqueue "github.com/abdulazizax/yelp/pkg/rabbitmq/rmq_queue"
+	"github.com/abdulazizax/yelp/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 // Run creates objects via constructors.
@@ -41,9 +46,76 @@ func Run(cfg *config.Config) {
 		l.Fatal(fmt.Errorf("app - Run - rediscache.New: %w", err))
 	}
 
+	redisClient := goredis.NewClient(&goredis.Options{
+		Addr: fmt.Sprintf("%s:%d", cfg.Redis.RedisHost, cfg.Redis.RedisPort),
+	})
+	defer redisClient.Close()
+
+	// file storage
+	var fileStorage storage.Storage
+	switch cfg.Storage.Driver {
+	case "s3":
+		fileStorage, err = storage.NewS3(context.Background(), storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			UseSSL:    cfg.Storage.S3UseSSL,
+			BaseURL:   cfg.Storage.S3PublicURL,
+		})
+	default:
+		fileStorage, err = storage.NewLocal(cfg.Storage.LocalDir, cfg.Storage.BaseURL)
+	}
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - storage: %w", err))
+	}
+
+	// mailer
+	var mail mailer.Mailer
+	switch cfg.Mailer.Driver {
+	case "log":
+		mail = mailer.NewLog(l)
+	case "memory":
+		mail = mailer.NewMemory()
+	default:
+		mail = mailer.NewSMTP(mailer.SMTPConfig{
+			Host:     cfg.Mailer.Host,
+			Port:     cfg.Mailer.Port,
+			Username: cfg.Mailer.Email,
+			Password: cfg.Mailer.EmailPass,
+			From:     cfg.Mailer.From,
+		})
+	}
+
+	// email queue, without RabbitMQ emails are sent within the request
+	var (
+		emailPublisher *rmqqueue.Publisher
+		emailWorker    *rmqqueue.Worker
+		workerNotify   <-chan error
+	)
+	if cfg.RMQ.URL != "" {
+		emailPublisher, err = rmqqueue.NewPublisher(cfg.RMQ.URL, cfg.RMQ.EmailQueue)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - rmqqueue.NewPublisher: %w", err))
+		}
+
+		emailWorker, err = rmqqueue.NewWorker(cfg.RMQ.URL, cfg.RMQ.EmailQueue,
+			map[string]rmqqueue.Handler{mailer.JobSend: mailer.Deliver(mail)}, l,
+			rmqqueue.MaxAttempts(cfg.RMQ.MaxAttempts),
+			rmqqueue.Backoff(cfg.RMQ.RetryBackoff, cfg.RMQ.MaxRetryBackoff),
+		)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - rmqqueue.NewWorker: %w", err))
+		}
+
+		workerNotify = emailWorker.Notify()
+		mail = mailer.NewQueue(emailPublisher)
+	}
+
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, l, cfg, useCase, redis)
+	v1.NewRouter(handler, l, cfg, useCase, redis, redisClient, fileStorage, mail)
 
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
@@ -58,6 +130,8 @@ func Run(cfg *config.Config) {
 		l.Info("app - Run - signal: %s", s.String())
 	case err = <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+	case err = <-workerNotify:
+		l.Error(fmt.Errorf("app - Run - emailWorker.Notify: %w", err))
 	}
 
 	// Shutdown
@@ -65,4 +139,16 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
+
+	if emailWorker != nil {
+		err = emailWorker.Shutdown()
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - emailWorker.Shutdown: %w", err))
+		}
+
+		err = emailPublisher.Close()
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - emailPublisher.Close: %w", err))
+		}
+	}
 }
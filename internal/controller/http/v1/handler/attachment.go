@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/pkg/imaging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// errAttachmentNotUploaded rejects attachments a business or review body tries to add by file path. Files are only
+// added through the attachment upload endpoints, which check them and store the resized variants of photos.
+var errAttachmentNotUploaded = errors.New("attachments must be uploaded through the attachment endpoint, the body only keeps uploaded ones by id")
+
+type attachmentMimeType struct {
+	ContentType string // attachment_type enum value
+	Extension   string
+}
+
+// attachmentMimeTypes lists the upload MIME types accepted for every attachment_type
+var attachmentMimeTypes = map[string]attachmentMimeType{
+	"image/jpeg": {ContentType: "photo", Extension: ".jpg"},
+	"image/png":  {ContentType: "photo", Extension: ".png"},
+	"image/gif":  {ContentType: "photo", Extension: ".gif"},
+	"image/webp": {ContentType: "photo", Extension: ".webp"},
+	"video/mp4":  {ContentType: "video", Extension: ".mp4"},
+	"video/webm": {ContentType: "video", Extension: ".webm"},
+}
+
+type storedFile struct {
+	Keys        []string
+	FilePath    string
+	ContentType string
+	MimeType    string
+	Variants    map[string]string
+}
+
+// storeUploadedFile validates the "file" field of a multipart request and saves it under prefix.
+// It writes the error response itself and returns false if the file could not be stored.
+func (h *Handler) storeUploadedFile(ctx *gin.Context, prefix string) (storedFile, bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.Config.Storage.MaxUploadSize)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid or too large file", http.StatusBadRequest)
+		return storedFile{}, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file", http.StatusBadRequest)
+		return storedFile{}, false
+	}
+	defer file.Close()
+
+	// http.DetectContentType needs at most the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file", http.StatusBadRequest)
+		return storedFile{}, false
+	}
+
+	mimeType := http.DetectContentType(head[:n])
+	attachmentType, ok := attachmentMimeTypes[mimeType]
+	if !ok {
+		h.ReturnError(ctx, config.ErrorInvalidRequest, fmt.Sprintf("Unsupported file type %s", mimeType), http.StatusBadRequest)
+		return storedFile{}, false
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return storedFile{}, false
+	}
+
+	// variants are generated before anything is stored, so an undecodable photo leaves nothing behind
+	var variants map[string][]byte
+	if attachmentType.ContentType == "photo" {
+		variants, err = imaging.GenerateVariants(file, imaging.DefaultVariants, h.Config.Storage.MaxImagePixels)
+		if errors.Is(err, imaging.ErrTooLarge) {
+			h.ReturnError(ctx, config.ErrorInvalidRequest,
+				fmt.Sprintf("Image must have at most %d pixels", h.Config.Storage.MaxImagePixels), http.StatusBadRequest)
+			return storedFile{}, false
+		}
+		if err != nil {
+			h.Logger.Error(err, "Error generating photo variants")
+			h.ReturnError(ctx, config.ErrorInvalidRequest, "Invalid image", http.StatusBadRequest)
+			return storedFile{}, false
+		}
+
+		_, err = file.Seek(0, io.SeekStart)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+			return storedFile{}, false
+		}
+	}
+
+	name := uuid.NewString()
+	response := storedFile{
+		ContentType: attachmentType.ContentType,
+		MimeType:    mimeType,
+	}
+
+	key := fmt.Sprintf("%s/%s%s", prefix, name, attachmentType.Extension)
+	response.FilePath, err = h.Storage.Put(ctx, key, file, fileHeader.Size, mimeType)
+	if err != nil {
+		h.Logger.Error(err, "Error storing uploaded file")
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error storing file", http.StatusInternalServerError)
+		return storedFile{}, false
+	}
+	response.Keys = append(response.Keys, key)
+
+	for variant, body := range variants {
+		key := fmt.Sprintf("%s/%s_%s.jpg", prefix, name, variant)
+		filePath, err := h.Storage.Put(ctx, key, bytes.NewReader(body), int64(len(body)), "image/jpeg")
+		if err != nil {
+			h.Logger.Error(err, "Error storing photo variant")
+			h.removeStoredFile(ctx, response)
+			h.ReturnError(ctx, config.ErrorInternalServer, "Error storing file", http.StatusInternalServerError)
+			return storedFile{}, false
+		}
+
+		if response.Variants == nil {
+			response.Variants = map[string]string{}
+		}
+		response.Variants[variant] = filePath
+		response.Keys = append(response.Keys, key)
+	}
+
+	return response, true
+}
+
+// removeStoredFile cleans up a stored file and its variants whose attachment row could not be saved
+func (h *Handler) removeStoredFile(ctx *gin.Context, file storedFile) {
+	for _, key := range file.Keys {
+		err := h.Storage.Delete(ctx, key)
+		if err != nil {
+			h.Logger.Error(err, "Error removing stored file")
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/abdulazizax/yelp/config"
@@ -10,7 +11,10 @@ import (
 	"github.com/abdulazizax/yelp/pkg/etc"
 	"github.com/abdulazizax/yelp/pkg/hash"
 	"github.com/abdulazizax/yelp/pkg/jwt"
+	"github.com/abdulazizax/yelp/pkg/mailer"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 // Login godoc
@@ -55,32 +59,12 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	// create session
-	newSession := entity.Session{
-		UserID:       user.ID,
-		IPAddress:    ctx.ClientIP(),
-		ExpiresAt:    time.Now().Add(time.Hour * 999999).Format(time.RFC3339),
-		UserAgent:    ctx.Request.UserAgent(),
-		IsActive:     true,
-		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
-	}
-
-	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
+	session, err := h.createSession(ctx, user, body.Platform)
 	if h.HandleDbError(ctx, err, "Error while creating new session") {
 		return
 	}
 
-	// generate jwt token
-	jwtFields := map[string]interface{}{
-		"sub":        user.ID,
-		"user_role":  user.UserRole,
-		"user_type":  user.UserType,
-		"platform":   body.Platform,
-		"session_id": session.ID,
-	}
-
-	user.AccessToken, err = jwt.GenerateJWT(jwtFields, h.Config.JWT.Secret)
+	user.AccessToken, err = h.generateAccessToken(user, session)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
 		return
@@ -172,24 +156,22 @@ func (h *Handler) Register(ctx *gin.Context) {
 	}
 
 	// send verification code to user
-	otp := etc.GenerateOTP(6)
-	err = h.Redis.Set(ctx, fmt.Sprintf("otp-%s", user.Email), otp, 5*60)
+	key := fmt.Sprintf("otp-%s", user.Email)
+	otp, err := h.issueOtp(ctx, key)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorInternalServer, "Error setting OTP", 500)
 		return
 	}
 
-	// send otp code to user's email
-	emailBody, err := etc.GenerateOtpEmailBody(otp)
+	_, err = h.otpCooldown(ctx, key)
 	if err != nil {
-		h.ReturnError(ctx, config.ErrorInternalServer, "Error sending OTP", 500)
-		return
+		h.Logger.Error(err, "Error starting OTP cooldown")
 	}
 
-	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, body.Email, emailBody)
+	// send otp code to user's email, the user can ask for a new one if it does not arrive
+	err = h.sendEmail(ctx, user.Email, mailer.TemplateOtp, mailer.Data{Name: user.FullName, Code: otp, ExpiresIn: int(config.OtpExpireTime.Minutes())})
 	if err != nil {
-		h.ReturnError(ctx, config.ErrorInternalServer, "Error sending OTP", 500)
-		return
+		h.Logger.Error(err, "Error sending OTP")
 	}
 
 	ctx.JSON(201, entity.SuccessResponse{
@@ -218,66 +200,413 @@ func (h *Handler) VerifyEmail(ctx *gin.Context) {
 		return
 	}
 
-	key := fmt.Sprintf("otp-%s", body.Email)
+	if !h.verifyOtp(ctx, fmt.Sprintf("otp-%s", body.Email), body.Otp) {
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{
+		Email: body.Email,
+	})
+	if h.HandleDbError(ctx, err, "get single user") {
+		return
+	}
+
+	user.Status = "active"
+
+	_, err = h.UseCase.UserRepo.Update(ctx, user)
+	if h.HandleDbError(ctx, err, "update user") {
+		return
+	}
+
+	err = h.sendEmail(ctx, user.Email, mailer.TemplateWelcome, mailer.Data{Name: user.FullName})
+	if err != nil {
+		h.Logger.Error(err, "Error sending welcome email")
+	}
+
+	session, err := h.createSession(ctx, user, body.Platform)
+	if h.HandleDbError(ctx, err, "Error while creating new session") {
+		return
+	}
+
+	user.AccessToken, err = h.generateAccessToken(user, session)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"user":    user,
+		"session": session,
+	})
+}
 
-	otp, err := h.Redis.Get(ctx, key)
+// RefreshToken godoc
+// @Router /auth/refresh [post]
+// @Summary Refresh access token
+// @Description Exchanges a refresh token for a new access token and a rotated refresh token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} entity.Session
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+func (h *Handler) RefreshToken(ctx *gin.Context) {
+	var (
+		body entity.RefreshTokenRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
 	if err != nil {
-		h.ReturnError(ctx, config.ErrorInternalServer, "Ooops, something went wrong", http.StatusInternalServerError)
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
 
-	if otp != body.Otp {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Incorrect otp", http.StatusBadRequest)
+	// refresh tokens are "<session id>.<secret>", only the hash of the secret is stored
+	sessionID, secret, found := strings.Cut(body.RefreshToken, ".")
+	if _, err := uuid.Parse(sessionID); !found || err != nil {
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Invalid refresh token", http.StatusUnauthorized)
 		return
 	}
 
+	session, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: sessionID})
+	if err == pgx.ErrNoRows {
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Invalid refresh token", http.StatusUnauthorized)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error getting session") {
+		return
+	}
+
+	if !session.IsActive || sessionExpired(session) {
+		h.ReturnError(ctx, config.ErrorSessionExpired, "Session is expired", http.StatusUnauthorized)
+		return
+	}
+
+	if etc.HashToken(secret) != session.RefreshTokenHash {
+		// session ids are not secret, only a token the session already rotated away from proves the token leaked
+		rotated, err := h.UseCase.SessionRepo.IsRotatedRefreshToken(ctx, session.ID, etc.HashToken(secret))
+		if h.HandleDbError(ctx, err, "Error checking refresh token") {
+			return
+		}
+
+		if !rotated {
+			h.ReturnError(ctx, config.ErrorInvalidToken, "Invalid refresh token", http.StatusUnauthorized)
+			return
+		}
+
+		h.revokeSession(ctx, session.ID)
+		h.alertSessionRevoked(ctx, session.UserID)
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Refresh token reuse detected, session revoked", http.StatusUnauthorized)
+		return
+	}
+
+	newSecret, err := etc.GenerateToken(32)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	session.ExpiresAt = time.Now().Add(config.TokenExpireTime).Format(time.RFC3339)
+
+	// the old hash must still be current, so of concurrent refreshes with the same token only one rotates it
+	res, err := h.UseCase.SessionRepo.RotateRefreshToken(ctx, entity.RefreshTokenRotation{
+		SessionID: session.ID,
+		OldHash:   session.RefreshTokenHash,
+		NewHash:   etc.HashToken(newSecret),
+		ExpiresAt: session.ExpiresAt,
+	})
+	if h.HandleDbError(ctx, err, "Error rotating refresh token") {
+		return
+	}
+
+	// the current token was presented twice, the other request rotated it first
+	if res.RowsEffected == 0 {
+		h.revokeSession(ctx, session.ID)
+		h.alertSessionRevoked(ctx, session.UserID)
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Refresh token reuse detected, session revoked", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: session.UserID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	session.RefreshToken = session.ID + "." + newSecret
+
+	accessToken, err := h.generateAccessToken(user, session)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"access_token": accessToken,
+		"session":      session,
+	})
+}
+
+// ForgotPassword godoc
+// @Router /auth/forgot-password [post]
+// @Summary Forgot password
+// @Description Sends a password reset code to the user's email
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.ForgotPasswordRequest true "Email"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ForgotPassword(ctx *gin.Context) {
+	var (
+		body entity.ForgotPasswordRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Email == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	// the response is the same whether the user exists or not, so emails can't be enumerated
+	response := entity.SuccessResponse{
+		Message: "If the email is registered, a password reset code has been sent",
+	}
+
 	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{
 		Email: body.Email,
 	})
+	if err == pgx.ErrNoRows {
+		ctx.JSON(200, response)
+		return
+	}
 	if h.HandleDbError(ctx, err, "get single user") {
 		return
 	}
 
-	user.Status = "active"
+	key := fmt.Sprintf("reset-otp-%s", user.Email)
+
+	ok, err := h.otpCooldown(ctx, key)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error setting OTP", 500)
+		return
+	}
+	if !ok {
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Please wait before requesting a new code", http.StatusTooManyRequests)
+		return
+	}
+
+	otp, err := h.issueOtp(ctx, key)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error setting OTP", 500)
+		return
+	}
+
+	err = h.sendEmail(ctx, user.Email, mailer.TemplatePasswordReset, mailer.Data{Name: user.FullName, Code: otp, ExpiresIn: int(config.OtpExpireTime.Minutes())})
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error sending OTP", 500)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+// ResetPassword godoc
+// @Router /auth/reset-password [post]
+// @Summary Reset password
+// @Description Sets a new password using the emailed reset code and signs out every session of the user
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.ResetPasswordRequest true "Reset password"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	var (
+		body entity.ResetPasswordRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Email == "" || body.Password == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.verifyOtp(ctx, fmt.Sprintf("reset-otp-%s", body.Email), body.Otp) {
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{
+		Email: body.Email,
+	})
+	if h.HandleDbError(ctx, err, "get single user") {
+		return
+	}
+
+	user.Password, err = hash.HashPassword(body.Password)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
 
 	_, err = h.UseCase.UserRepo.Update(ctx, user)
 	if h.HandleDbError(ctx, err, "update user") {
 		return
 	}
 
-	// create session
-	newSession := entity.Session{
-		UserID:       user.ID,
-		IPAddress:    ctx.ClientIP(),
-		ExpiresAt:    time.Now().Add(time.Hour * 999999).Format(time.RFC3339),
-		UserAgent:    ctx.Request.UserAgent(),
-		IsActive:     true,
-		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
+	_, err = h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "user_id", Type: "eq", Value: user.ID},
+			{Column: "is_active", Type: "eq", Value: "true"},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "is_active", Value: "false"},
+			{Column: "updated_at", Value: "now()"},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error deactivating sessions") {
+		return
 	}
 
-	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
-	if h.HandleDbError(ctx, err, "Error while creating new session") {
+	err = h.sendEmail(ctx, user.Email, mailer.TemplateSecurityAlert, mailer.Data{Name: user.FullName, Event: mailer.EventPasswordChanged})
+	if err != nil {
+		h.Logger.Error(err, "Error sending security alert")
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Password has been reset, please login again",
+	})
+}
+
+// ResendOtp godoc
+// @Router /auth/resend-otp [post]
+// @Summary Resend verification code
+// @Description Sends a new email verification code, at most once per cooldown period
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.ResendOtpRequest true "Email"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) ResendOtp(ctx *gin.Context) {
+	var (
+		body entity.ResendOtpRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Email == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{
+		Email: body.Email,
+	})
+	if h.HandleDbError(ctx, err, "get single user") {
+		return
+	}
+
+	if user.Status != "inverify" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Email is already verified", http.StatusBadRequest)
+		return
+	}
+
+	key := fmt.Sprintf("otp-%s", user.Email)
+
+	ok, err := h.otpCooldown(ctx, key)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error setting OTP", 500)
+		return
+	}
+	if !ok {
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Please wait before requesting a new code", http.StatusTooManyRequests)
 		return
 	}
 
-	// generate jwt token
+	otp, err := h.issueOtp(ctx, key)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error setting OTP", 500)
+		return
+	}
+
+	err = h.sendEmail(ctx, user.Email, mailer.TemplateOtp, mailer.Data{Name: user.FullName, Code: otp, ExpiresIn: int(config.OtpExpireTime.Minutes())})
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error sending OTP", 500)
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Verification code has been sent",
+	})
+}
+
+// createSession opens a session for the user and sets its refresh token
+func (h *Handler) createSession(ctx *gin.Context, user entity.User, platform string) (entity.Session, error) {
+	secret, err := etc.GenerateToken(32)
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	session, err := h.UseCase.SessionRepo.Create(ctx, entity.Session{
+		UserID:           user.ID,
+		IPAddress:        ctx.ClientIP(),
+		ExpiresAt:        time.Now().Add(config.TokenExpireTime).Format(time.RFC3339),
+		UserAgent:        ctx.Request.UserAgent(),
+		IsActive:         true,
+		LastActiveAt:     time.Now().Format(time.RFC3339),
+		Platform:         platform,
+		RefreshTokenHash: etc.HashToken(secret),
+	})
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	session.RefreshToken = session.ID + "." + secret
+
+	return session, nil
+}
+
+func (h *Handler) generateAccessToken(user entity.User, session entity.Session) (string, error) {
 	jwtFields := map[string]interface{}{
 		"sub":        user.ID,
 		"user_role":  user.UserRole,
 		"user_type":  user.UserType,
-		"platform":   body.Platform,
+		"platform":   session.Platform,
 		"session_id": session.ID,
 	}
 
-	user.AccessToken, err = jwt.GenerateJWT(jwtFields, h.Config.JWT.Secret)
+	return jwt.GenerateJWT(jwtFields, h.Config.JWT.Secret, h.Config.JWT.AccessTokenTTL)
+}
+
+func (h *Handler) revokeSession(ctx *gin.Context, sessionID string) {
+	_, err := h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: sessionID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "is_active", Value: "false"},
+			{Column: "updated_at", Value: "now()"},
+		},
+	})
 	if err != nil {
-		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		h.Logger.Error(err, "Error revoking session")
+	}
+}
+
+// alertSessionRevoked tells the user that one of their sessions was signed out for security reasons
+func (h *Handler) alertSessionRevoked(ctx *gin.Context, userID string) {
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: userID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting user for security alert")
 		return
 	}
 
-	ctx.JSON(200, gin.H{
-		"user":    user,
-		"session": session,
-	})
+	err = h.sendEmail(ctx, user.Email, mailer.TemplateSecurityAlert, mailer.Data{Name: user.FullName, Event: mailer.EventSessionRevoked})
+	if err != nil {
+		h.Logger.Error(err, "Error sending security alert")
+	}
+}
+
+func sessionExpired(session entity.Session) bool {
+	expiresAt, err := time.Parse(time.RFC3339, session.ExpiresAt)
+	return err != nil || time.Now().After(expiresAt)
 }
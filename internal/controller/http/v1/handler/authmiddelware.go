@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +16,7 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			userRole string
+			tokenErr error
 			act      = c.Request.Method
 			obj      = c.FullPath()
 		)
@@ -30,6 +32,7 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 			claims, err := jwt.ParseJWT(token, h.Config.JWT.Secret)
 			if err != nil {
 				userRole = "unauthorized"
+				tokenErr = err
 			}
 
 			v, ok := claims["user_role"].(string)
@@ -58,6 +61,11 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session is not active"})
 				return
 			}
+
+			if sessionExpired(session) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session is expired"})
+				return
+			}
 		}
 
 		ok, err := e.EnforceSafe(userRole, obj, act)
@@ -67,6 +75,17 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 			return
 		}
 
+		// a bad token still reaches public routes (e.g. /auth/refresh), everything else gets 401
+		if !ok && errors.Is(tokenErr, jwt.ErrTokenExpired) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token is expired"})
+			return
+		}
+
+		if !ok && tokenErr != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token is invalid"})
+			return
+		}
+
 		if !ok {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
 			return
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CreateBusinessAttribute godoc
+// @Router /business-category/{id}/attribute [post]
+// @Summary Define an attribute for businesses of a category
+// @Description A boolean attribute is an amenity (wifi, parking), an enum attribute takes one of its options
+// @Security BearerAuth
+// @Tags business-category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "BusinessCategory ID"
+// @Param attribute body entity.BusinessAttribute true "BusinessAttribute object"
+// @Success 201 {object} entity.BusinessAttribute
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateBusinessAttribute(ctx *gin.Context) {
+	var (
+		body entity.BusinessAttribute
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, only admin can define business attributes", http.StatusForbidden)
+		return
+	}
+
+	body.CategoryID = ctx.Param("id")
+	if err := normalizeBusinessAttribute(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.UseCase.BusinessCategoryRepo.GetSingle(ctx, entity.BusinessCategorySingleRequest{ID: body.CategoryID})
+	if h.HandleDbError(ctx, err, "Error getting business-category") {
+		return
+	}
+
+	attribute, err := h.UseCase.BusinessAttributeRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating business attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attribute)
+}
+
+// GetBusinessAttributes godoc
+// @Router /business-category/{id}/attribute/list [get]
+// @Summary Get the attributes defined for a category
+// @Description Get the attributes of a category, including the ones it inherits from the categories above it
+// @Security BearerAuth
+// @Tags business-category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "BusinessCategory ID"
+// @Success 200 {object} entity.BusinessAttributeList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessAttributes(ctx *gin.Context) {
+	attributes, err := h.categoryAttributes(ctx, ctx.Param("id"))
+	if h.HandleDbError(ctx, err, "Error getting business attributes") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// UpdateBusinessAttribute godoc
+// @Router /business-category/attribute [put]
+// @Summary Update a business attribute
+// @Description Update a business attribute, values already stored on businesses are checked again on their next update
+// @Security BearerAuth
+// @Tags business-category
+// @Accept  json
+// @Produce  json
+// @Param attribute body entity.BusinessAttribute true "BusinessAttribute object"
+// @Success 200 {object} entity.BusinessAttribute
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateBusinessAttribute(ctx *gin.Context) {
+	var (
+		body entity.BusinessAttribute
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, only admin can update business attributes", http.StatusForbidden)
+		return
+	}
+
+	current, err := h.UseCase.BusinessAttributeRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting business attribute") {
+		return
+	}
+
+	body.CategoryID = current.CategoryID
+	if err := normalizeBusinessAttribute(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	attribute, err := h.UseCase.BusinessAttributeRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating business attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attribute)
+}
+
+// DeleteBusinessAttribute godoc
+// @Router /business-category/attribute/{id} [delete]
+// @Summary Delete a business attribute
+// @Description Delete a business attribute
+// @Security BearerAuth
+// @Tags business-category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "BusinessAttribute ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteBusinessAttribute(ctx *gin.Context) {
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, only admin can delete business attributes", http.StatusForbidden)
+		return
+	}
+
+	err := h.UseCase.BusinessAttributeRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting business attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Business attribute deleted successfully",
+	})
+}
+
+// categoryAttributes lists the attributes a business in the category may have: its own and the ones of the categories
+// above it, an attribute defined again lower in the tree replaces the inherited one
+func (h *Handler) categoryAttributes(ctx *gin.Context, categoryID string) (entity.BusinessAttributeList, error) {
+	var response entity.BusinessAttributeList
+
+	categoryIDs, err := h.UseCase.BusinessCategoryRepo.GetAncestorIDs(ctx, entity.Id{ID: categoryID})
+	if err != nil {
+		return response, err
+	}
+
+	var levels [][]entity.BusinessAttribute
+	for _, id := range categoryIDs {
+		attributes, err := h.UseCase.BusinessAttributeRepo.GetList(ctx, entity.GetListFilter{
+			Limit: config.MaxCategoryAttributes,
+			Filters: []entity.Filter{
+				{Column: "category_id", Type: "eq", Value: id},
+			},
+			OrderBy: []entity.OrderBy{
+				{Column: "key", Order: "asc"},
+			},
+		})
+		if err != nil {
+			return response, err
+		}
+		levels = append(levels, attributes.Items)
+	}
+
+	response.Items = mergeCategoryAttributes(levels)
+	response.Count = len(response.Items)
+
+	return response, nil
+}
+
+// mergeCategoryAttributes joins the attributes of a category and its ancestors, the nearest level first,
+// keeping the nearest definition of every key and ordering them by key
+func mergeCategoryAttributes(levels [][]entity.BusinessAttribute) []entity.BusinessAttribute {
+	items := []entity.BusinessAttribute{}
+	for _, level := range levels {
+		for _, attribute := range level {
+			if !slices.ContainsFunc(items, func(a entity.BusinessAttribute) bool { return a.Key == attribute.Key }) {
+				items = append(items, attribute)
+			}
+		}
+	}
+
+	slices.SortFunc(items, func(a, b entity.BusinessAttribute) int { return strings.Compare(a.Key, b.Key) })
+
+	return items
+}
+
+// validBusinessAttributes checks the price level and attribute values of a business against its categories,
+// answering the request when they are invalid
+func (h *Handler) validBusinessAttributes(ctx *gin.Context, business *entity.Business) bool {
+	if business.PriceLevel != nil && (*business.PriceLevel < config.MinPriceLevel || *business.PriceLevel > config.MaxPriceLevel) {
+		h.ReturnError(ctx, config.ErrorBadRequest,
+			fmt.Sprintf("price_level must be between %d and %d", config.MinPriceLevel, config.MaxPriceLevel), http.StatusBadRequest)
+		return false
+	}
+
+	if business.Attributes == nil {
+		business.Attributes = map[string]interface{}{}
+	}
+	if len(business.Attributes) == 0 {
+		return true
+	}
+
+	var definitions []entity.BusinessAttribute
+	for _, categoryID := range business.CategoryIDs {
+		attributes, err := h.categoryAttributes(ctx, categoryID)
+		if h.HandleDbError(ctx, err, "Error getting business attributes") {
+			return false
+		}
+		definitions = append(definitions, attributes.Items...)
+	}
+
+	if err := validateAttributeValues(definitions, business.Attributes); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func validateAttributeValues(definitions []entity.BusinessAttribute, values map[string]interface{}) error {
+	byKey := map[string]entity.BusinessAttribute{}
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	for key, value := range values {
+		definition, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("attribute %s is not defined for the business categories", key)
+		}
+
+		switch definition.Type {
+		case config.AttributeTypeBoolean:
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("attribute %s must be true or false", key)
+			}
+		case config.AttributeTypeEnum:
+			option, _ := value.(string)
+			if !slices.Contains(definition.Options, option) {
+				return fmt.Errorf("attribute %s must be one of %v", key, definition.Options)
+			}
+		}
+	}
+
+	return nil
+}
+
+func normalizeBusinessAttribute(attribute *entity.BusinessAttribute) error {
+	if !attributeKeyPattern.MatchString(attribute.Key) {
+		return errors.New("key must be lower case letters, digits and underscores")
+	}
+	if attribute.Name == "" {
+		return errors.New("name is required")
+	}
+
+	switch attribute.Type {
+	case config.AttributeTypeBoolean:
+		if len(attribute.Options) > 0 {
+			return errors.New("a boolean attribute has no options")
+		}
+		attribute.Options = []string{}
+	case config.AttributeTypeEnum:
+		if len(attribute.Options) == 0 {
+			return errors.New("an enum attribute needs options")
+		}
+		for i, option := range attribute.Options {
+			if option == "" || slices.Contains(attribute.Options[:i], option) {
+				return fmt.Errorf("option %q is empty or repeated", option)
+			}
+		}
+	default:
+		return fmt.Errorf("type must be %s or %s", config.AttributeTypeBoolean, config.AttributeTypeEnum)
+	}
+
+	return nil
+}
+
+// parsePriceLevels reads a comma separated price filter such as "1,2"
+func parsePriceLevels(price string) ([]int, error) {
+	var levels []int
+	for _, item := range strings.Split(price, ",") {
+		level, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || level < config.MinPriceLevel || level > config.MaxPriceLevel {
+			return nil, fmt.Errorf("price must be a comma separated list of levels between %d and %d", config.MinPriceLevel, config.MaxPriceLevel)
+		}
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+// parseAttributeFilters reads amenity=wifi,parking and attribute=noise_level:quiet filters into the values businesses must carry
+func parseAttributeFilters(amenities string, attributes []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	if amenities != "" {
+		for _, key := range strings.Split(amenities, ",") {
+			key = strings.TrimSpace(key)
+			if !attributeKeyPattern.MatchString(key) {
+				return nil, fmt.Errorf("amenity %q is not a valid attribute key", key)
+			}
+			values[key] = true
+		}
+	}
+
+	for _, attribute := range attributes {
+		key, value, ok := strings.Cut(attribute, ":")
+		if !ok || !attributeKeyPattern.MatchString(key) || value == "" {
+			return nil, errors.New("attribute must look like key:value")
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+func TestMergeCategoryAttributes(t *testing.T) {
+	pizza := []entity.BusinessAttribute{
+		{CategoryID: "pizza", Key: "wood_fired"},
+		{CategoryID: "pizza", Key: "delivery"},
+	}
+	italian := []entity.BusinessAttribute{
+		{CategoryID: "italian", Key: "pasta"},
+	}
+	restaurants := []entity.BusinessAttribute{
+		{CategoryID: "restaurants", Key: "delivery"},
+		{CategoryID: "restaurants", Key: "wifi"},
+	}
+
+	tests := []struct {
+		name   string
+		levels [][]entity.BusinessAttribute
+		want   []string // category:key
+	}{
+		{
+			name:   "inherited from every level",
+			levels: [][]entity.BusinessAttribute{pizza, italian, restaurants},
+			want:   []string{"pizza:delivery", "italian:pasta", "restaurants:wifi", "pizza:wood_fired"},
+		},
+		{
+			name:   "category without attributes of its own",
+			levels: [][]entity.BusinessAttribute{nil, restaurants},
+			want:   []string{"restaurants:delivery", "restaurants:wifi"},
+		},
+		{
+			name: "no attributes",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, attribute := range mergeCategoryAttributes(tt.levels) {
+				got = append(got, attribute.CategoryID+":"+attribute.Key)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeCategoryAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/abdulazizax/yelp/config"
@@ -68,6 +69,12 @@ func (h *Handler) GetBusinessCategory(ctx *gin.Context) {
 		return
 	}
 
+	attributes, err := h.categoryAttributes(ctx, businessCategory.ID)
+	if h.HandleDbError(ctx, err, "Error getting business attributes") {
+		return
+	}
+	businessCategory.Attributes = attributes.Items
+
 	ctx.JSON(200, businessCategory)
 }
 
@@ -82,6 +89,7 @@ func (h *Handler) GetBusinessCategory(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param parent_id query string false "only the direct subcategories of the category"
 // @Success 200 {object} entity.BusinessCategoryList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBusinessCategories(ctx *gin.Context) {
@@ -103,6 +111,14 @@ func (h *Handler) GetBusinessCategories(ctx *gin.Context) {
 		},
 	)
 
+	if parentID := ctx.DefaultQuery("parent_id", ""); parentID != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "parent_id",
+			Type:   "eq",
+			Value:  parentID,
+		})
+	}
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
@@ -116,6 +132,25 @@ func (h *Handler) GetBusinessCategories(ctx *gin.Context) {
 	ctx.JSON(200, users)
 }
 
+// GetBusinessCategoryTree godoc
+// @Router /business-category/tree [get]
+// @Summary Get the category tree
+// @Description Get the top level categories with their subcategories nested as children
+// @Security BearerAuth
+// @Tags business-category
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} entity.BusinessCategoryList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessCategoryTree(ctx *gin.Context) {
+	tree, err := h.UseCase.BusinessCategoryRepo.GetTree(ctx)
+	if h.HandleDbError(ctx, err, "Error getting business-category tree") {
+		return
+	}
+
+	ctx.JSON(200, tree)
+}
+
 // UpdateBusinessCategory godoc
 // @Router /business-category [put]
 // @Summary Update a business-category
@@ -143,6 +178,17 @@ func (h *Handler) UpdateBusinessCategory(ctx *gin.Context) {
 		return
 	}
 
+	if body.ParentID != "" {
+		descendants, err := h.UseCase.BusinessCategoryRepo.GetDescendantIDs(ctx, entity.Id{ID: body.ID})
+		if h.HandleDbError(ctx, err, "Error getting business-category tree") {
+			return
+		}
+		if slices.Contains(descendants, body.ParentID) {
+			h.ReturnError(ctx, config.ErrorBadRequest, "A category can not be moved below itself or its subcategories", 400)
+			return
+		}
+	}
+
 	businessCategory, err := h.UseCase.BusinessCategoryRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating business-category") {
 		return
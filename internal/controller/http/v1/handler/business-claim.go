@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// ClaimBusiness godoc
+// @Router /business/{id}/claim [post]
+// @Summary Claim a business
+// @Description Asks to become the owner of the business, an admin approves or rejects the claim
+// @Security BearerAuth
+// @Tags business-claim
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param claim body entity.BusinessClaimRequest true "Proof of ownership"
+// @Success 201 {object} entity.BusinessClaim
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ClaimBusiness(ctx *gin.Context) {
+	var (
+		body entity.BusinessClaimRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	if business.OwnerID == ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorBadRequest, "You already own this business", http.StatusBadRequest)
+		return
+	}
+
+	claim, err := h.UseCase.BusinessClaimRepo.Create(ctx, entity.BusinessClaim{
+		BusinessID: business.ID,
+		UserID:     ctx.GetHeader("sub"),
+		Message:    body.Message,
+	})
+	if h.HandleDbError(ctx, err, "Error creating business claim") {
+		return
+	}
+
+	ctx.JSON(201, claim)
+}
+
+// GetBusinessClaims godoc
+// @Router /business-claim/list [get]
+// @Summary Get a list of business claims
+// @Description Admins see every claim, other users their own
+// @Security BearerAuth
+// @Tags business-claim
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param status query string false "claim status" Enums(pending, approved, rejected)
+// @Success 200 {object} entity.BusinessClaimList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessClaims(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	if status := ctx.Query("status"); status != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "status", Type: "eq", Value: status})
+	}
+
+	if ctx.GetHeader("user_type") != "admin" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "user_id", Type: "eq", Value: ctx.GetHeader("sub")})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	claims, err := h.UseCase.BusinessClaimRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting business claims") {
+		return
+	}
+
+	ctx.JSON(200, claims)
+}
+
+// GetBusinessClaim godoc
+// @Router /business-claim/{id} [get]
+// @Summary Get a business claim by ID
+// @Description Get a business claim with its history
+// @Security BearerAuth
+// @Tags business-claim
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Claim ID"
+// @Success 200 {object} entity.BusinessClaim
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessClaim(ctx *gin.Context) {
+	claim, err := h.UseCase.BusinessClaimRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business claim") {
+		return
+	}
+
+	if claim.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the claim", http.StatusForbidden)
+		return
+	}
+
+	ctx.JSON(200, claim)
+}
+
+// ApproveBusinessClaim godoc
+// @Router /business-claim/{id}/approve [post]
+// @Summary Approve a business claim
+// @Description Transfers the business to the claimant, promotes them to business_owner and rejects the other pending claims of the business
+// @Security BearerAuth
+// @Tags business-claim
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Claim ID"
+// @Param decision body entity.BusinessClaimDecision false "Note"
+// @Success 200 {object} entity.BusinessClaim
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ApproveBusinessClaim(ctx *gin.Context) {
+	decision, ok := h.claimDecision(ctx)
+	if !ok {
+		return
+	}
+
+	claim, err := h.UseCase.BusinessClaimRepo.Approve(ctx, decision)
+	if err == pgx.ErrNoRows {
+		h.ReturnError(ctx, config.ErrorConflict, "The claim is not pending", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error approving business claim") {
+		return
+	}
+
+	ctx.JSON(200, claim)
+}
+
+// RejectBusinessClaim godoc
+// @Router /business-claim/{id}/reject [post]
+// @Summary Reject a business claim
+// @Description Reject a business claim
+// @Security BearerAuth
+// @Tags business-claim
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Claim ID"
+// @Param decision body entity.BusinessClaimDecision false "Note"
+// @Success 200 {object} entity.BusinessClaim
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RejectBusinessClaim(ctx *gin.Context) {
+	decision, ok := h.claimDecision(ctx)
+	if !ok {
+		return
+	}
+
+	claim, err := h.UseCase.BusinessClaimRepo.Reject(ctx, decision)
+	if err == pgx.ErrNoRows {
+		h.ReturnError(ctx, config.ErrorConflict, "The claim is not pending", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error rejecting business claim") {
+		return
+	}
+
+	ctx.JSON(200, claim)
+}
+
+// claimDecision reads the optional note of the admin and checks that the claim exists
+func (h *Handler) claimDecision(ctx *gin.Context) (entity.BusinessClaimDecision, bool) {
+	var (
+		decision entity.BusinessClaimDecision
+	)
+
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&decision)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+			return decision, false
+		}
+	}
+
+	claim, err := h.UseCase.BusinessClaimRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business claim") {
+		return decision, false
+	}
+
+	decision.ClaimID = claim.ID
+	decision.ReviewerID = ctx.GetHeader("sub")
+
+	return decision, true
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	_ "time/tzdata" // business time zones are validated without relying on the host zoneinfo
+
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+// normalizeBusinessHours validates the opening hours of a business and fills the defaults the database expects
+func normalizeBusinessHours(business *entity.Business) error {
+	if business.TimeZone == "" {
+		business.TimeZone = "UTC"
+	}
+	// "Local" is the zone of the API server, the database does not know it
+	if _, err := time.LoadLocation(business.TimeZone); err != nil || business.TimeZone == "Local" {
+		return fmt.Errorf("unknown time_zone %q", business.TimeZone)
+	}
+
+	for day, ranges := range business.HoursOfOperation.Days() {
+		if err := validateTimeRanges(ranges); err != nil {
+			return fmt.Errorf("hours_of_operation.%s: %w", day, err)
+		}
+	}
+
+	// a day left out is closed, it is stored as an empty list rather than JSON null
+	hours := &business.HoursOfOperation
+	for _, ranges := range []*[]entity.TimeRange{
+		&hours.Monday, &hours.Tuesday, &hours.Wednesday, &hours.Thursday, &hours.Friday, &hours.Saturday, &hours.Sunday,
+	} {
+		if *ranges == nil {
+			*ranges = []entity.TimeRange{}
+		}
+	}
+
+	if business.SpecialHours == nil {
+		business.SpecialHours = []entity.SpecialHours{}
+	}
+
+	dates := map[string]bool{}
+	for i := range business.SpecialHours {
+		special := &business.SpecialHours[i]
+		if special.Hours == nil {
+			special.Hours = []entity.TimeRange{}
+		}
+
+		if _, err := time.Parse("2006-01-02", special.Date); err != nil {
+			return fmt.Errorf("special_hours: date %q must be YYYY-MM-DD", special.Date)
+		}
+		if dates[special.Date] {
+			return fmt.Errorf("special_hours: date %s is listed twice", special.Date)
+		}
+		dates[special.Date] = true
+
+		if special.Closed && len(special.Hours) > 0 {
+			return fmt.Errorf("special_hours.%s: a closed day can not have hours", special.Date)
+		}
+		if err := validateTimeRanges(special.Hours); err != nil {
+			return fmt.Errorf("special_hours.%s: %w", special.Date, err)
+		}
+	}
+
+	return nil
+}
+
+func validateTimeRanges(ranges []entity.TimeRange) error {
+	for _, r := range ranges {
+		opens, err := parseClock(r.Open)
+		if err != nil || opens == 24*60 {
+			return fmt.Errorf("open %q must be HH:MM between 00:00 and 23:59", r.Open)
+		}
+
+		closes, err := parseClock(r.Close)
+		if err != nil {
+			return fmt.Errorf("close %q must be HH:MM between 00:00 and 24:00", r.Close)
+		}
+
+		if opens == closes {
+			return fmt.Errorf("range %s-%s is empty, use 00:00-24:00 for a whole day", r.Open, r.Close)
+		}
+	}
+
+	return nil
+}
+
+// parseClock returns the minutes since midnight of an "HH:MM" value, 24:00 is allowed as the end of the day
+func parseClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, errors.New("invalid time")
+	}
+
+	hours, err := strconv.Atoi(value[:2])
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := strconv.Atoi(value[3:])
+	if err != nil {
+		return 0, err
+	}
+
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, errors.New("invalid time")
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// parseOpenAt resolves the open_now and open_at query parameters into the moment businesses must be open at
+func parseOpenAt(openNow, openAt string) (*time.Time, error) {
+	if openNow != "" && openAt != "" {
+		return nil, errors.New("open_now and open_at can not be combined")
+	}
+
+	if openAt != "" {
+		at, err := time.Parse(time.RFC3339, openAt)
+		if err != nil {
+			return nil, errors.New("open_at must be an RFC3339 timestamp")
+		}
+		return &at, nil
+	}
+
+	if openNow != "" {
+		isOpen, err := strconv.ParseBool(openNow)
+		if err != nil {
+			return nil, errors.New("open_now must be true or false")
+		}
+		if isOpen {
+			now := time.Now()
+			return &now, nil
+		}
+	}
+
+	return nil, nil
+}
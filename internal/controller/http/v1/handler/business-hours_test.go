@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "00:00", want: 0},
+		{value: "09:30", want: 570},
+		{value: "23:59", want: 1439},
+		{value: "24:00", want: 1440},
+		{value: "24:01", wantErr: true},
+		{value: "25:00", wantErr: true},
+		{value: "12:60", wantErr: true},
+		{value: "9:30", wantErr: true},
+		{value: "09.30", wantErr: true},
+		{value: "-1:00", wantErr: true},
+		{value: "ab:cd", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseClock(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClock(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseClock(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimeRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranges  []entity.TimeRange
+		wantErr bool
+	}{
+		{name: "none", ranges: nil},
+		{name: "day", ranges: []entity.TimeRange{{Open: "09:00", Close: "18:00"}}},
+		{name: "split day", ranges: []entity.TimeRange{{Open: "09:00", Close: "13:00"}, {Open: "14:00", Close: "18:00"}}},
+		{name: "overnight", ranges: []entity.TimeRange{{Open: "22:00", Close: "02:00"}}},
+		{name: "whole day", ranges: []entity.TimeRange{{Open: "00:00", Close: "24:00"}}},
+		{name: "opens at 24:00", ranges: []entity.TimeRange{{Open: "24:00", Close: "02:00"}}, wantErr: true},
+		{name: "empty range", ranges: []entity.TimeRange{{Open: "09:00", Close: "09:00"}}, wantErr: true},
+		{name: "bad close", ranges: []entity.TimeRange{{Open: "09:00", Close: "6pm"}}, wantErr: true},
+		{name: "second range is bad", ranges: []entity.TimeRange{{Open: "09:00", Close: "13:00"}, {Open: "14:00"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTimeRanges(tt.ranges)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTimeRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseOpenAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		openNow string
+		openAt  string
+		want    *time.Time
+		wantNow bool
+		wantErr bool
+	}{
+		{name: "no filter"},
+		{name: "open now", openNow: "true", wantNow: true},
+		{name: "open now off", openNow: "false"},
+		{name: "open at", openAt: "2026-12-31T23:30:00+05:00", want: timePtr(time.Date(2026, 12, 31, 18, 30, 0, 0, time.UTC))},
+		{name: "both", openNow: "true", openAt: "2026-12-31T23:30:00+05:00", wantErr: true},
+		{name: "bad open now", openNow: "yes please", wantErr: true},
+		{name: "open at without zone", openAt: "2026-12-31T23:30:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+
+			got, err := parseOpenAt(tt.openNow, tt.openAt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOpenAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			switch {
+			case tt.wantNow:
+				if got == nil || got.Before(before) || got.After(time.Now()) {
+					t.Errorf("parseOpenAt() = %v, want the current time", got)
+				}
+			case tt.want != nil:
+				if got == nil || !got.Equal(*tt.want) {
+					t.Errorf("parseOpenAt() = %v, want %v", got, tt.want)
+				}
+			case got != nil:
+				t.Errorf("parseOpenAt() = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestNormalizeBusinessHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		business entity.Business
+		wantErr  bool
+	}{
+		{name: "nothing set", business: entity.Business{}},
+		{
+			name: "some days left out",
+			business: entity.Business{
+				TimeZone:         "Asia/Tashkent",
+				HoursOfOperation: entity.HoursOfOperation{Monday: []entity.TimeRange{{Open: "09:00", Close: "18:00"}}},
+				SpecialHours:     []entity.SpecialHours{{Date: "2026-12-31"}, {Date: "2027-01-01", Closed: true}},
+			},
+		},
+		{name: "local time zone", business: entity.Business{TimeZone: "Local"}, wantErr: true},
+		{name: "unknown time zone", business: entity.Business{TimeZone: "Mars/Olympus"}, wantErr: true},
+		{name: "bad date", business: entity.Business{SpecialHours: []entity.SpecialHours{{Date: "31.12.2026"}}}, wantErr: true},
+		{name: "date twice", business: entity.Business{SpecialHours: []entity.SpecialHours{{Date: "2026-12-31"}, {Date: "2026-12-31"}}}, wantErr: true},
+		{
+			name: "closed day with hours",
+			business: entity.Business{SpecialHours: []entity.SpecialHours{
+				{Date: "2026-12-31", Closed: true, Hours: []entity.TimeRange{{Open: "09:00", Close: "12:00"}}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := tt.business
+
+			err := normalizeBusinessHours(&business)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeBusinessHours() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if business.TimeZone == "" {
+				t.Error("TimeZone was left empty")
+			}
+			// the database reads every day and special day as a list, never as JSON null
+			for day, ranges := range business.HoursOfOperation.Days() {
+				if ranges == nil {
+					t.Errorf("hours_of_operation.%s is nil", day)
+				}
+			}
+			if business.SpecialHours == nil {
+				t.Error("special_hours is nil")
+			}
+			for _, special := range business.SpecialHours {
+				if special.Hours == nil {
+					t.Errorf("special_hours.%s hours are nil", special.Date)
+				}
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/abdulazizax/yelp/config"
@@ -11,7 +14,7 @@ import (
 // CreateBusiness godoc
 // @Router /business [post]
 // @Summary Create a new business
-// @Description Create a new business
+// @Description Create a new business, attachments are added afterwards through POST /business/{id}/attachment
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
@@ -30,8 +33,32 @@ func (h *Handler) CreateBusiness(ctx *gin.Context) {
 		return
 	}
 
+	if slices.ContainsFunc(body.Attachments, func(a entity.BusinessAttachment) bool { return a.Id == "" }) {
+		h.ReturnError(ctx, config.ErrorBadRequest, errAttachmentNotUploaded.Error(), 400)
+		return
+	}
+
 	body.OwnerID = ctx.GetHeader("sub")
 
+	if err := validateCoordinates(body.Latitude, body.Longitude); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if err := normalizeBusinessHours(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if err := normalizeBusinessCategories(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if !h.validBusinessAttributes(ctx, &body) {
+		return
+	}
+
 	business, err := h.UseCase.BusinessRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating business") {
 		return
@@ -85,54 +112,155 @@ func (h *Handler) GetBusiness(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param lat query number false "latitude of the search center"
+// @Param lng query number false "longitude of the search center"
+// @Param radius_km query number false "search radius in kilometers"
+// @Param min_lat query number false "south edge of the bounding box, the four edges go together"
+// @Param max_lat query number false "north edge of the bounding box"
+// @Param min_lng query number false "west edge of the bounding box, above max_lng when the box crosses the antimeridian"
+// @Param max_lng query number false "east edge of the bounding box"
+// @Param min_rating query number false "minimum average rating"
+// @Param min_reviews query number false "minimum number of reviews"
+// @Param open_now query boolean false "only businesses open right now"
+// @Param open_at query string false "only businesses open at the given RFC3339 time"
+// @Param category_id query string false "category, businesses of its subcategories are included"
+// @Param price query string false "comma separated price levels, e.g. 1,2"
+// @Param amenity query string false "comma separated amenities the business must have, e.g. wifi,parking"
+// @Param attribute query []string false "attribute values as key:value, e.g. noise_level:quiet" collectionFormat(multi)
+// @Param sort_by query string false "sort column" Enums(created_at, avg_rating, review_count, distance_m)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, page is ignored when set"
+// @Param with_count query boolean false "include the total count, on by default without a cursor"
+// @Param filter query string false "filter[column][op]=value, op is eq (default), neq, gt, gte, lt, lte or contains. Columns: name, address, owner_id, avg_rating, review_count, check_in_count, price_level, created_at"
+// @Param sort query string false "comma separated columns, a leading - sorts descending. Columns: name, avg_rating, review_count, check_in_count, created_at"
 // @Success 200 {object} entity.BusinessList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBusinesses(ctx *gin.Context) {
 	var (
-		req entity.GetListFilter
+		req entity.BusinessListFilter
 	)
 
 	page := ctx.DefaultQuery("page", "1")
 	limit := ctx.DefaultQuery("limit", "10")
 	search := ctx.DefaultQuery("search", "")
+	lat := ctx.DefaultQuery("lat", "")
+	lng := ctx.DefaultQuery("lng", "")
+
+	if lat != "" || lng != "" {
+		geo, err := parseGeoFilter(lat, lng, ctx.DefaultQuery("radius_km", ""))
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+			return
+		}
+		req.Geo = &geo
+	}
+
+	if ctx.Query("min_lat") != "" || ctx.Query("max_lat") != "" || ctx.Query("min_lng") != "" || ctx.Query("max_lng") != "" {
+		box, err := parseBoundingBox(ctx.Query("min_lat"), ctx.Query("max_lat"), ctx.Query("min_lng"), ctx.Query("max_lng"))
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+			return
+		}
+		req.BoundingBox = &box
+	}
+
+	openAt, err := parseOpenAt(ctx.DefaultQuery("open_now", ""), ctx.DefaultQuery("open_at", ""))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+	req.OpenAt = openAt
+
+	req.CategoryID = ctx.DefaultQuery("category_id", "")
+
+	if price := ctx.DefaultQuery("price", ""); price != "" {
+		req.PriceLevels, err = parsePriceLevels(price)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+			return
+		}
+	}
+
+	req.Attributes, err = parseAttributeFilters(ctx.DefaultQuery("amenity", ""), ctx.QueryArray("attribute"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
 	req.Filters = append(req.Filters,
 		entity.Filter{
-			Column: "name",
-			Type:   "search",
+			Column: "search_vector",
+			Type:   "fts",
 			Value:  search,
 		},
 		entity.Filter{
-			Column: "address",
-			Type:   "search",
+			Column: "name",
+			Type:   "similar",
 			Value:  search,
 		},
 		entity.Filter{
-			Column: "description",
-			Type:   "search",
+			Column: "address",
+			Type:   "similar",
 			Value:  search,
 		},
 	)
 
-	req.OrderBy = append(req.OrderBy, entity.OrderBy{
-		Column: "created_at",
-		Order:  "desc",
-	})
+	if minRating := ctx.DefaultQuery("min_rating", ""); minRating != "" {
+		if _, err := strconv.ParseFloat(minRating, 64); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "min_rating must be a number", 400)
+			return
+		}
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "avg_rating",
+			Type:   "gte",
+			Value:  minRating,
+		})
+	}
+
+	if minReviews := ctx.DefaultQuery("min_reviews", ""); minReviews != "" {
+		if _, err := strconv.Atoi(minReviews); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "min_reviews must be an integer", 400)
+			return
+		}
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "review_count",
+			Type:   "gte",
+			Value:  minReviews,
+		})
+	}
+
+	orderBy, err := parseBusinessOrderBy(ctx.DefaultQuery("sort_by", ""), ctx.DefaultQuery("order", "desc"), req.Geo != nil)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+	req.OrderBy = append(req.OrderBy, orderBy)
+
+	if err := parseListQuery(ctx.Request.URL.Query(), businessListColumns, &req.GetListFilter); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if !h.pageRequest(ctx, &req.GetListFilter) {
+		return
+	}
 
 	users, err := h.UseCase.BusinessRepo.GetList(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting users") {
 		return
 	}
 
+	h.signPage(&users.Page)
+
 	ctx.JSON(200, users)
 }
 
 // UpdateBusiness godoc
 // @Router /business [put]
 // @Summary Update a business
-// @Description Update a business
+// @Description Update a business, attachments left out of the body are deleted and new ones are rejected
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
@@ -151,11 +279,35 @@ func (h *Handler) UpdateBusiness(ctx *gin.Context) {
 		return
 	}
 
+	if slices.ContainsFunc(body.Attachments, func(a entity.BusinessAttachment) bool { return a.Id == "" }) {
+		h.ReturnError(ctx, config.ErrorBadRequest, errAttachmentNotUploaded.Error(), 400)
+		return
+	}
+
 	if ctx.GetHeader("sub") != body.OwnerID || ctx.GetHeader("user_type") != "admin" {
 		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, only owner or admin can update business", 403)
 		return
 	}
 
+	if err := validateCoordinates(body.Latitude, body.Longitude); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if err := normalizeBusinessHours(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if err := normalizeBusinessCategories(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if !h.validBusinessAttributes(ctx, &body) {
+		return
+	}
+
 	business, err := h.UseCase.BusinessRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating business") {
 		return
@@ -210,3 +362,167 @@ func (h *Handler) DeleteBusiness(ctx *gin.Context) {
 		Message: "Business deleted successfully",
 	})
 }
+
+func parseGeoFilter(lat, lng, radiusKm string) (entity.GeoFilter, error) {
+	var (
+		geo = entity.GeoFilter{RadiusKm: config.DefaultSearchRadiusKm}
+		err error
+	)
+
+	geo.Latitude, err = strconv.ParseFloat(lat, 64)
+	if err != nil || geo.Latitude < -90 || geo.Latitude > 90 {
+		return geo, errors.New("lat must be a number between -90 and 90")
+	}
+
+	geo.Longitude, err = strconv.ParseFloat(lng, 64)
+	if err != nil || geo.Longitude < -180 || geo.Longitude > 180 {
+		return geo, errors.New("lng must be a number between -180 and 180")
+	}
+
+	if radiusKm != "" {
+		geo.RadiusKm, err = strconv.ParseFloat(radiusKm, 64)
+		if err != nil || geo.RadiusKm <= 0 || geo.RadiusKm > config.MaxSearchRadiusKm {
+			return geo, fmt.Errorf("radius_km must be a number between 0 and %v", config.MaxSearchRadiusKm)
+		}
+	}
+
+	return geo, nil
+}
+
+// validateCoordinates checks a point that may be left out, latitude and longitude come together or not at all
+func validateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return errors.New("latitude and longitude must be sent together")
+	}
+	if latitude != nil && (*latitude < -90 || *latitude > 90 || *longitude < -180 || *longitude > 180) {
+		return errors.New("latitude must be between -90 and 90, longitude between -180 and 180")
+	}
+
+	return nil
+}
+
+// parseBoundingBox reads the edges of a map viewport, all four are required
+func parseBoundingBox(minLat, maxLat, minLng, maxLng string) (entity.BoundingBox, error) {
+	var (
+		box entity.BoundingBox
+		err error
+	)
+
+	if minLat == "" || maxLat == "" || minLng == "" || maxLng == "" {
+		return box, errors.New("min_lat, max_lat, min_lng and max_lng must be sent together")
+	}
+
+	for _, edge := range []struct {
+		name  string
+		value string
+		limit float64
+		dest  *float64
+	}{
+		{"min_lat", minLat, 90, &box.MinLatitude},
+		{"max_lat", maxLat, 90, &box.MaxLatitude},
+		{"min_lng", minLng, 180, &box.MinLongitude},
+		{"max_lng", maxLng, 180, &box.MaxLongitude},
+	} {
+		*edge.dest, err = strconv.ParseFloat(edge.value, 64)
+		if err != nil || *edge.dest < -edge.limit || *edge.dest > edge.limit {
+			return box, fmt.Errorf("%s must be a number between %v and %v", edge.name, -edge.limit, edge.limit)
+		}
+	}
+
+	if box.MinLatitude > box.MaxLatitude {
+		return box, errors.New("min_lat must not be above max_lat")
+	}
+
+	return box, nil
+}
+
+// normalizeBusinessCategories makes the primary category part of the category list, defaulting it to the first listed one
+func normalizeBusinessCategories(business *entity.Business) error {
+	if business.CategoryID == "" && len(business.CategoryIDs) > 0 {
+		business.CategoryID = business.CategoryIDs[0]
+	}
+	if business.CategoryID == "" {
+		return errors.New("category_id is required")
+	}
+
+	categoryIDs := []string{business.CategoryID}
+	for _, categoryID := range business.CategoryIDs {
+		if categoryID != "" && !slices.Contains(categoryIDs, categoryID) {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+
+	if len(categoryIDs) > config.MaxBusinessCategories {
+		return fmt.Errorf("a business can be listed under at most %d categories", config.MaxBusinessCategories)
+	}
+
+	business.CategoryIDs = categoryIDs
+
+	return nil
+}
+
+func parseBusinessOrderBy(sortBy, order string, hasGeo bool) (entity.OrderBy, error) {
+	if order != "asc" && order != "desc" {
+		return entity.OrderBy{}, errors.New("order must be asc or desc")
+	}
+
+	switch sortBy {
+	case "":
+		if hasGeo {
+			return entity.OrderBy{Column: "distance_m", Order: "asc"}, nil
+		}
+		return entity.OrderBy{Column: "created_at", Order: "desc"}, nil
+	case "distance_m":
+		if !hasGeo {
+			return entity.OrderBy{}, errors.New("sorting by distance_m requires lat and lng")
+		}
+	case "created_at", "avg_rating", "review_count":
+	default:
+		return entity.OrderBy{}, errors.New("sort_by must be one of created_at, avg_rating, review_count, distance_m")
+	}
+
+	return entity.OrderBy{Column: sortBy, Order: order}, nil
+}
+
+// UploadBusinessAttachment godoc
+// @Router /business/{id}/attachment [post]
+// @Summary Upload a business photo or video
+// @Description Upload a business photo or video
+// @Security BearerAuth
+// @Tags business
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param file formData file true "Photo or video"
+// @Success 201 {object} entity.BusinessAttachment
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UploadBusinessAttachment(ctx *gin.Context) {
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business not found", 404)
+		return
+	}
+
+	if business.OwnerID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, only owner or admin can upload business attachments", 403)
+		return
+	}
+
+	file, ok := h.storeUploadedFile(ctx, "businesses/"+business.ID)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.UseCase.BusinessAttachmentRepo.Create(ctx, entity.BusinessAttachment{
+		BusinessId:  business.ID,
+		FilePath:    file.FilePath,
+		ContentType: file.ContentType,
+		Variants:    file.Variants,
+	})
+	if h.HandleDbError(ctx, err, "Error creating business attachment") {
+		h.removeStoredFile(ctx, file)
+		return
+	}
+
+	ctx.JSON(201, attachment)
+}
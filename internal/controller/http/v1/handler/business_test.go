@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+func TestParseBoundingBox(t *testing.T) {
+	tests := []struct {
+		name                           string
+		minLat, maxLat, minLng, maxLng string
+		want                           entity.BoundingBox
+		wantErr                        bool
+	}{
+		{name: "viewport", minLat: "41.2", maxLat: "41.4", minLng: "69.1", maxLng: "69.3",
+			want: entity.BoundingBox{MinLatitude: 41.2, MaxLatitude: 41.4, MinLongitude: 69.1, MaxLongitude: 69.3}},
+		{name: "crosses the antimeridian", minLat: "-20", maxLat: "-10", minLng: "170", maxLng: "-170",
+			want: entity.BoundingBox{MinLatitude: -20, MaxLatitude: -10, MinLongitude: 170, MaxLongitude: -170}},
+		{name: "missing edge", minLat: "41.2", maxLat: "41.4", minLng: "69.1", wantErr: true},
+		{name: "not a number", minLat: "north", maxLat: "41.4", minLng: "69.1", maxLng: "69.3", wantErr: true},
+		{name: "latitude out of range", minLat: "-91", maxLat: "41.4", minLng: "69.1", maxLng: "69.3", wantErr: true},
+		{name: "longitude out of range", minLat: "41.2", maxLat: "41.4", minLng: "69.1", maxLng: "181", wantErr: true},
+		{name: "inverted latitudes", minLat: "41.4", maxLat: "41.2", minLng: "69.1", maxLng: "69.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBoundingBox(tt.minLat, tt.maxLat, tt.minLng, tt.maxLng)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBoundingBox() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseBoundingBox() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCoordinates(t *testing.T) {
+	point := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name                string
+		latitude, longitude *float64
+		wantErr             bool
+	}{
+		{name: "left out"},
+		{name: "point", latitude: point(41.311081), longitude: point(69.240562)},
+		{name: "null island is a point", latitude: point(0), longitude: point(0)},
+		{name: "latitude only", latitude: point(41.3), wantErr: true},
+		{name: "longitude only", longitude: point(69.2), wantErr: true},
+		{name: "latitude out of range", latitude: point(91), longitude: point(69.2), wantErr: true},
+		{name: "longitude out of range", latitude: point(41.3), longitude: point(-181), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCoordinates(tt.latitude, tt.longitude)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCoordinates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/etc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// CheckIn godoc
+// @Router /business/{id}/check-in [post]
+// @Summary Check in at a business
+// @Description When latitude and longitude are sent they must be close to the business.
+// @Description A user can check in to the same business once per cooldown period.
+// @Security BearerAuth
+// @Tags check-in
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param check-in body entity.CheckInRequest false "Location of the user"
+// @Success 201 {object} entity.CheckIn
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) CheckIn(ctx *gin.Context) {
+	var (
+		body entity.CheckInRequest
+	)
+
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&body)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := validateCoordinates(body.Latitude, body.Longitude); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	checkIn := entity.CheckIn{
+		UserID:     ctx.GetHeader("sub"),
+		BusinessID: business.ID,
+		Latitude:   body.Latitude,
+		Longitude:  body.Longitude,
+	}
+
+	// businesses saved without coordinates can not be checked against
+	if body.Latitude != nil && business.Latitude != nil {
+		distance := etc.DistanceM(*body.Latitude, *body.Longitude, *business.Latitude, *business.Longitude)
+		if distance > h.Config.CheckIn.MaxDistance {
+			h.ReturnError(ctx, config.ErrorBadRequest,
+				fmt.Sprintf("You are %.0f meters away, check-ins are allowed within %.0f meters of the business", distance, h.Config.CheckIn.MaxDistance),
+				http.StatusBadRequest)
+			return
+		}
+		checkIn.DistanceM = &distance
+	}
+
+	checkIn, err = h.UseCase.CheckInRepo.Create(ctx, checkIn)
+	if err == pgx.ErrNoRows {
+		h.ReturnError(ctx, config.ErrorTooManyRequests,
+			fmt.Sprintf("You already checked in here, you can check in again %s after your last check-in", h.Config.CheckIn.Cooldown),
+			http.StatusTooManyRequests)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error creating check-in") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, checkIn)
+}
+
+// GetCheckIns godoc
+// @Router /check-in/list [get]
+// @Summary Get check-in history
+// @Description The caller's check-ins, newest first. Admins can list the check-ins of any user.
+// @Security BearerAuth
+// @Tags check-in
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param user_id query string false "user, the caller by default"
+// @Param business_id query string false "only check-ins at this business"
+// @Success 200 {object} entity.CheckInList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCheckIns(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	userID := ctx.DefaultQuery("user_id", ctx.GetHeader("sub"))
+	if userID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Access denied, check-ins are private", http.StatusForbidden)
+		return
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{Column: "c.user_id", Type: "eq", Value: userID})
+	if businessID := ctx.Query("business_id"); businessID != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "c.business_id", Type: "eq", Value: businessID})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{Column: "c.created_at", Order: "desc"})
+
+	checkIns, err := h.UseCase.CheckInRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting check-ins") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checkIns)
+}
@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCollection godoc
+// @Router /collection [post]
+// @Summary Create a collection
+// @Description Create a collection of businesses, private unless is_public is set
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param collection body entity.Collection true "Collection object"
+// @Success 201 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateCollection(ctx *gin.Context) {
+	var (
+		body entity.Collection
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Name == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	body.UserID = ctx.GetHeader("sub")
+	body.IsDefault = false
+
+	collection, err := h.UseCase.CollectionRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, collection)
+}
+
+// GetCollection godoc
+// @Router /collection/{id} [get]
+// @Summary Get a collection with its businesses
+// @Description Private collections are only shown to their owner
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entity.Collection
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetCollection(ctx *gin.Context) {
+	collection, err := h.UseCase.CollectionRepo.GetSingle(ctx, entity.CollectionSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting collection") {
+		return
+	}
+
+	if !collection.IsPublic && collection.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorNotFound, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	collection.Items, err = h.UseCase.CollectionRepo.GetItems(ctx, entity.Id{ID: collection.ID})
+	if h.HandleDbError(ctx, err, "Error getting collection businesses") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// GetCollections godoc
+// @Router /collection/list [get]
+// @Summary Get a list of collections
+// @Description Own collections by default, only the public ones of other users
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param user_id query string false "owner of the collections, the caller by default"
+// @Success 200 {object} entity.CollectionList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCollections(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	userID := ctx.DefaultQuery("user_id", ctx.GetHeader("sub"))
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "user_id",
+		Type:   "eq",
+		Value:  userID,
+	})
+
+	if userID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "is_public",
+			Type:   "eq",
+			Value:  "true",
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{Column: "is_default", Order: "desc"},
+		entity.OrderBy{Column: "created_at", Order: "desc"},
+	)
+
+	collections, err := h.UseCase.CollectionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting collections") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
+
+// UpdateCollection godoc
+// @Router /collection [put]
+// @Summary Update a collection
+// @Description Rename a collection or change its visibility
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param collection body entity.Collection true "Collection object"
+// @Success 200 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateCollection(ctx *gin.Context) {
+	var (
+		body entity.Collection
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Name == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := h.ownedCollection(ctx, body.ID); !ok {
+		return
+	}
+
+	collection, err := h.UseCase.CollectionRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// DeleteCollection godoc
+// @Router /collection/{id} [delete]
+// @Summary Delete a collection
+// @Description Deleting the bookmarks collection empties it, it is created again on the next bookmark
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteCollection(ctx *gin.Context) {
+	collection, ok := h.ownedCollection(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	err := h.UseCase.CollectionRepo.Delete(ctx, entity.Id{ID: collection.ID})
+	if h.HandleDbError(ctx, err, "Error deleting collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Collection deleted successfully",
+	})
+}
+
+// AddCollectionBusiness godoc
+// @Router /collection/{id}/business [post]
+// @Summary Add a business to a collection
+// @Description The business is added to the end of the collection
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param item body entity.CollectionItemRequest true "Business to add"
+// @Success 201 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) AddCollectionBusiness(ctx *gin.Context) {
+	collection, ok := h.ownedCollection(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	h.addCollectionBusiness(ctx, collection)
+}
+
+// BookmarkBusiness godoc
+// @Router /collection/bookmark [post]
+// @Summary Bookmark a business
+// @Description Saves the business to the bookmarks collection, which is created on the first bookmark
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param item body entity.CollectionItemRequest true "Business to bookmark"
+// @Success 201 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) BookmarkBusiness(ctx *gin.Context) {
+	collection, err := h.UseCase.CollectionRepo.GetDefault(ctx, entity.Id{ID: ctx.GetHeader("sub")})
+	if h.HandleDbError(ctx, err, "Error getting bookmarks") {
+		return
+	}
+
+	h.addCollectionBusiness(ctx, collection)
+}
+
+// RemoveCollectionBusiness godoc
+// @Router /collection/{id}/business/{business_id} [delete]
+// @Summary Remove a business from a collection
+// @Description Remove a business from a collection
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param business_id path string true "Business ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) RemoveCollectionBusiness(ctx *gin.Context) {
+	collection, ok := h.ownedCollection(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	err := h.UseCase.CollectionRepo.RemoveItem(ctx, entity.CollectionItemRequest{
+		CollectionID: collection.ID,
+		BusinessID:   ctx.Param("business_id"),
+	})
+	if h.HandleDbError(ctx, err, "Error removing business from collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Business removed from collection",
+	})
+}
+
+// ReorderCollection godoc
+// @Router /collection/{id}/order [put]
+// @Summary Reorder the businesses of a collection
+// @Description business_ids must list every business of the collection exactly once, in the new order
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param order body entity.CollectionOrderRequest true "New order"
+// @Success 200 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ReorderCollection(ctx *gin.Context) {
+	var (
+		body entity.CollectionOrderRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	collection, ok := h.ownedCollection(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	items, err := h.UseCase.CollectionRepo.GetItems(ctx, entity.Id{ID: collection.ID})
+	if h.HandleDbError(ctx, err, "Error getting collection businesses") {
+		return
+	}
+
+	if !sameBusinesses(items, body.BusinessIDs) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "business_ids must list every business of the collection exactly once", http.StatusBadRequest)
+		return
+	}
+
+	body.CollectionID = collection.ID
+	err = h.UseCase.CollectionRepo.Reorder(ctx, body)
+	if h.HandleDbError(ctx, err, "Error reordering collection") {
+		return
+	}
+
+	collection.Items, err = h.UseCase.CollectionRepo.GetItems(ctx, entity.Id{ID: collection.ID})
+	if h.HandleDbError(ctx, err, "Error getting collection businesses") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+func (h *Handler) addCollectionBusiness(ctx *gin.Context, collection entity.Collection) {
+	var (
+		body entity.CollectionItemRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.BusinessID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	body.CollectionID = collection.ID
+	err = h.UseCase.CollectionRepo.AddItem(ctx, body)
+	if h.HandleDbError(ctx, err, "Error adding business to collection") {
+		return
+	}
+
+	collection.Items, err = h.UseCase.CollectionRepo.GetItems(ctx, entity.Id{ID: collection.ID})
+	if h.HandleDbError(ctx, err, "Error getting collection businesses") {
+		return
+	}
+	collection.ItemCount = len(collection.Items)
+
+	ctx.JSON(http.StatusCreated, collection)
+}
+
+// ownedCollection loads the collection and checks that the caller owns it
+func (h *Handler) ownedCollection(ctx *gin.Context, id string) (entity.Collection, bool) {
+	collection, err := h.UseCase.CollectionRepo.GetSingle(ctx, entity.CollectionSingleRequest{ID: id})
+	if h.HandleDbError(ctx, err, "Error getting collection") {
+		return entity.Collection{}, false
+	}
+
+	if collection.UserID != ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only the owner can change a collection", http.StatusForbidden)
+		return entity.Collection{}, false
+	}
+
+	return collection, true
+}
+
+func sameBusinesses(items []entity.CollectionItem, businessIDs []string) bool {
+	if len(items) != len(businessIDs) {
+		return false
+	}
+
+	for i, item := range items {
+		if !slices.Contains(businessIDs, item.BusinessID) || slices.Contains(businessIDs[:i], businessIDs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
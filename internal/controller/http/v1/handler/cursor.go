@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidCursor = errors.New("cursor is invalid")
+
+// signPageCursor turns a list position into an opaque token, the signature keeps clients from forging positions
+func (h *Handler) signPageCursor(cursor entity.PageCursor) string {
+	payload, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(h.cursorMAC(payload))
+}
+
+// verifyPageCursor reads a token made by signPageCursor for a list sorted by order
+func (h *Handler) verifyPageCursor(token, order string) (*entity.PageCursor, error) {
+	var cursor entity.PageCursor
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, h.cursorMAC(payload)) {
+		return nil, errInvalidCursor
+	}
+
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.Order != order {
+		return nil, errors.New("cursor does not match the sort order of the list")
+	}
+
+	return &cursor, nil
+}
+
+func (h *Handler) cursorMAC(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte("cursor:"+h.Config.JWT.Secret))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// pageRequest reads the cursor and with_count parameters of a list into req, req.OrderBy must already be set.
+// The total count is left out of cursor pages unless with_count asks for it.
+func (h *Handler) pageRequest(ctx *gin.Context, req *entity.GetListFilter) bool {
+	var err error
+
+	if token := ctx.Query("cursor"); token != "" {
+		req.Cursor, err = h.verifyPageCursor(token, req.OrderKey())
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+			return false
+		}
+	}
+
+	withCount := req.Cursor == nil
+	if value := ctx.Query("with_count"); value != "" {
+		withCount, err = strconv.ParseBool(value)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "with_count must be true or false", http.StatusBadRequest)
+			return false
+		}
+	}
+	req.SkipCount = !withCount
+
+	return true
+}
+
+// signPage turns the positions a repository left in page into cursors
+func (h *Handler) signPage(page *entity.Page) {
+	if page.Next != nil {
+		page.NextCursor = h.signPageCursor(*page.Next)
+	}
+	if page.Prev != nil {
+		page.PrevCursor = h.signPageCursor(*page.Prev)
+	}
+}
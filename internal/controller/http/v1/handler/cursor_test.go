@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+func cursorHandler(secret string) *Handler {
+	cfg := &config.Config{}
+	cfg.JWT.Secret = secret
+
+	return &Handler{Config: cfg}
+}
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	h := cursorHandler("secret")
+
+	tests := []struct {
+		name   string
+		cursor entity.PageCursor
+	}{
+		{
+			name:   "forward",
+			cursor: entity.PageCursor{Order: "created_at desc", Values: []string{"2024-05-01 10:00:00", "7f1c0c5e-0000-4000-8000-000000000001"}},
+		},
+		{
+			name:   "backward",
+			cursor: entity.PageCursor{Order: "rating asc,created_at desc", Values: []string{"5", "2024-05-01 10:00:00", "id"}, Backward: true},
+		},
+		{
+			name:   "default order",
+			cursor: entity.PageCursor{Values: []string{"id"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := h.signPageCursor(tt.cursor)
+
+			got, err := h.verifyPageCursor(token, tt.cursor.Order)
+			if err != nil {
+				t.Fatalf("verifyPageCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.cursor) {
+				t.Errorf("verifyPageCursor() = %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestVerifyPageCursorRejects(t *testing.T) {
+	h := cursorHandler("secret")
+	cursor := entity.PageCursor{Order: "created_at desc", Values: []string{"2024-05-01 10:00:00", "id"}}
+	token := h.signPageCursor(cursor)
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	forged, _ := json.Marshal(entity.PageCursor{Order: cursor.Order, Values: []string{"2099-01-01 00:00:00", "id"}})
+
+	tests := []struct {
+		name    string
+		token   string
+		order   string
+		wantErr string
+	}{
+		{
+			name:    "changed values",
+			token:   base64.RawURLEncoding.EncodeToString(forged) + "." + signature,
+			order:   cursor.Order,
+			wantErr: errInvalidCursor.Error(),
+		},
+		{
+			name:    "changed signature",
+			token:   encoded + "." + base64.RawURLEncoding.EncodeToString([]byte("forged")),
+			order:   cursor.Order,
+			wantErr: errInvalidCursor.Error(),
+		},
+		{
+			name:    "signed with another secret",
+			token:   cursorHandler("other").signPageCursor(cursor),
+			order:   cursor.Order,
+			wantErr: errInvalidCursor.Error(),
+		},
+		{
+			name:    "no signature",
+			token:   encoded,
+			order:   cursor.Order,
+			wantErr: errInvalidCursor.Error(),
+		},
+		{
+			name:    "not base64",
+			token:   "!!!." + signature,
+			order:   cursor.Order,
+			wantErr: errInvalidCursor.Error(),
+		},
+		{
+			name:    "other sort",
+			token:   token,
+			order:   "created_at asc",
+			wantErr: "cursor does not match the sort order of the list",
+		},
+		{
+			name:    "sort added",
+			token:   token,
+			order:   "rating desc,created_at desc",
+			wantErr: "cursor does not match the sort order of the list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.verifyPageCursor(tt.token, tt.order)
+			if err == nil {
+				t.Fatalf("verifyPageCursor() = %+v, want error %q", got, tt.wantErr)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("verifyPageCursor() error = %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
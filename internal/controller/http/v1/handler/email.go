@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/abdulazizax/yelp/pkg/mailer"
+	"github.com/gin-gonic/gin"
+)
+
+// sendEmail renders the template in the caller's language and sends it to the recipient
+func (h *Handler) sendEmail(ctx *gin.Context, to, template string, data mailer.Data) error {
+	msg, err := h.Templates.Render(template, emailLocale(ctx), data)
+	if err != nil {
+		return err
+	}
+
+	msg.To = []string{to}
+
+	return h.Mailer.Send(ctx, msg)
+}
+
+// emailLocale takes the preferred language of the Accept-Language header, e.g. "ru" of "ru-RU,ru;q=0.9"
+func emailLocale(ctx *gin.Context) string {
+	first, _, _ := strings.Cut(ctx.GetHeader("Accept-Language"), ",")
+	locale, _, _ := strings.Cut(first, ";")
+
+	return strings.TrimSpace(locale)
+}
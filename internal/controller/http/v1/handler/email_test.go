@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdulazizax/yelp/pkg/mailer"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSendEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		template       string
+		data           mailer.Data
+		wantSubject    string
+		wantText       string
+	}{
+		{
+			name:        "default locale",
+			template:    mailer.TemplateOtp,
+			data:        mailer.Data{Code: "123456", ExpiresIn: 5},
+			wantSubject: "Your Yelp verification code",
+			wantText:    "123456",
+		},
+		{
+			name:           "preferred language",
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
+			template:       mailer.TemplatePasswordReset,
+			data:           mailer.Data{Code: "654321", ExpiresIn: 5},
+			wantSubject:    "Код для сброса пароля Yelp",
+			wantText:       "654321",
+		},
+		{
+			name:           "unknown language falls back",
+			acceptLanguage: "de-DE",
+			template:       mailer.TemplateWelcome,
+			data:           mailer.Data{Name: "Aziz"},
+			wantSubject:    "Welcome to Yelp, Aziz!",
+			wantText:       "Hi Aziz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail := mailer.NewMemory()
+			h := &Handler{Mailer: mail, Templates: mailer.NewDefaultTemplates("en")}
+
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("POST", "/", nil)
+			ctx.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			err := h.sendEmail(ctx, "user@example.com", tt.template, tt.data)
+			if err != nil {
+				t.Fatalf("sendEmail() error = %v", err)
+			}
+
+			msg, ok := mail.Last("user@example.com")
+			if !ok {
+				t.Fatal("sendEmail() sent nothing to user@example.com")
+			}
+			if msg.Subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", msg.Subject, tt.wantSubject)
+			}
+			if !strings.Contains(msg.Text, tt.wantText) || !strings.Contains(msg.HTML, tt.wantText) {
+				t.Errorf("message does not contain %q:\n%s", tt.wantText, msg.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// FollowUser godoc
+// @Router /user/{id}/follow [post]
+// @Summary Follow a user
+// @Description Their reviews and photos show up in the caller's feed, following someone twice is not an error
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) FollowUser(ctx *gin.Context) {
+	req := entity.FollowRequest{
+		FollowerID: ctx.GetHeader("sub"),
+		FolloweeID: ctx.Param("id"),
+	}
+
+	if req.FollowerID == req.FolloweeID {
+		h.ReturnError(ctx, config.ErrorBadRequest, "You can not follow yourself", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: req.FolloweeID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	err = h.UseCase.FollowRepo.Create(ctx, req)
+	if h.HandleDbError(ctx, err, "Error following user") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User followed successfully",
+	})
+}
+
+// UnfollowUser godoc
+// @Router /user/{id}/follow [delete]
+// @Summary Unfollow a user
+// @Description Unfollow a user
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) UnfollowUser(ctx *gin.Context) {
+	err := h.UseCase.FollowRepo.Delete(ctx, entity.FollowRequest{
+		FollowerID: ctx.GetHeader("sub"),
+		FolloweeID: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error unfollowing user") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User unfollowed successfully",
+	})
+}
+
+// GetFollowers godoc
+// @Router /user/{id}/followers [get]
+// @Summary Get the followers of a user
+// @Description Newest followers first
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.FollowList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFollowers(ctx *gin.Context) {
+	req, ok := h.followListRequest(ctx)
+	if !ok {
+		return
+	}
+
+	followers, err := h.UseCase.FollowRepo.GetFollowers(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting followers") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, followers)
+}
+
+// GetFollowing godoc
+// @Router /user/{id}/following [get]
+// @Summary Get the users a user follows
+// @Description Most recently followed first
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.FollowList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFollowing(ctx *gin.Context) {
+	req, ok := h.followListRequest(ctx)
+	if !ok {
+		return
+	}
+
+	following, err := h.UseCase.FollowRepo.GetFollowing(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting followed users") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, following)
+}
+
+// GetFeed godoc
+// @Router /feed [get]
+// @Summary Get the activity feed
+// @Description Recent reviews and review photos of the users the caller follows, newest first.
+// @Description Pass next_cursor of a page as cursor to get the following page, it is missing on the last page.
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.FeedPage
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFeed(ctx *gin.Context) {
+	var (
+		req entity.FeedRequest
+		err error
+	)
+
+	req.UserID = ctx.GetHeader("sub")
+	req.Limit, err = strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(config.FeedDefaultLimit)))
+	if err != nil || req.Limit < 1 || req.Limit > config.FeedMaxLimit {
+		h.ReturnError(ctx, config.ErrorBadRequest, "limit must be between 1 and "+strconv.Itoa(config.FeedMaxLimit), http.StatusBadRequest)
+		return
+	}
+
+	if token := ctx.Query("cursor"); token != "" {
+		req.Cursor, err = h.verifyPageCursor(token, config.FeedOrder)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	feed, err := h.UseCase.FollowRepo.GetFeed(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting feed") {
+		return
+	}
+
+	h.signPage(&feed.Page)
+	ctx.JSON(http.StatusOK, feed)
+}
+
+func (h *Handler) followListRequest(ctx *gin.Context) (entity.FollowListRequest, bool) {
+	var (
+		req entity.FollowListRequest
+	)
+
+	req.UserID = ctx.Param("id")
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	_, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: req.UserID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return req, false
+	}
+
+	return req, true
+}
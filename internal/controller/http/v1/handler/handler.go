@@ -4,21 +4,36 @@ import (
 	"github.com/abdulazizax/yelp/config"
 	"github.com/abdulazizax/yelp/internal/usecase"
 	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/mailer"
+	"github.com/abdulazizax/yelp/pkg/screening"
+	"github.com/abdulazizax/yelp/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
+	"github.com/redis/go-redis/v9"
 )
 
 type Handler struct {
-	Logger  *logger.Logger
-	Config  *config.Config
-	UseCase *usecase.UseCase
-	Redis   rediscache.RedisCache
+	Logger      *logger.Logger
+	Config      *config.Config
+	UseCase     *usecase.UseCase
+	Redis       rediscache.RedisCache
+	RedisClient redis.Cmdable // for the atomic counters and SET NX that RedisCache lacks
+	Storage     storage.Storage
+	Mailer      mailer.Mailer
+	Templates   *mailer.Templates
+	Screener    *screening.Pipeline
 }
 
-func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) *Handler {
+func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache,
+	redisClient redis.Cmdable, storage storage.Storage, mail mailer.Mailer) *Handler {
 	return &Handler{
-		Logger:  l,
-		Config:  c,
-		UseCase: useCase,
-		Redis:   redis,
+		Logger:      l,
+		Config:      c,
+		UseCase:     useCase,
+		Redis:       redis,
+		RedisClient: redisClient,
+		Storage:     storage,
+		Mailer:      mail,
+		Templates:   mailer.NewDefaultTemplates(c.Mailer.DefaultLocale),
+		Screener:    newReviewScreener(c, useCase),
 	}
 }
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/google/uuid"
+)
+
+// listColumn is a column clients may filter or sort a list by through the query string.
+// Only the columns of a list's whitelist reach the SQL, and values are checked against the column kind.
+type listColumn struct {
+	column   string
+	kind     string
+	ops      []string
+	options  []string // allowed values of an enum column
+	sortable bool     // cursors compare the values of a sorted column
+	nullable bool     // the column may be NULL, which a cursor can not compare, so it is never sorted by
+}
+
+const (
+	kindText   = "text"
+	kindInt    = "int"
+	kindNumber = "number"
+	kindTime   = "time"
+	kindBool   = "bool"
+	kindUUID   = "uuid"
+)
+
+var (
+	compareOps = []string{"eq", "neq", "gt", "gte", "lt", "lte"}
+	equalOps   = []string{"eq", "neq"}
+	textOps    = []string{"eq", "neq", "contains"}
+)
+
+var userListColumns = map[string]listColumn{
+	"full_name":  {column: "full_name", kind: kindText, ops: textOps, sortable: true},
+	"username":   {column: "username", kind: kindText, ops: textOps, sortable: true},
+	"email":      {column: "email", kind: kindText, ops: textOps},
+	"user_type":  {column: "user_type", kind: kindText, ops: equalOps, options: []string{"user", "admin"}},
+	"user_role":  {column: "user_role", kind: kindText, ops: equalOps, options: []string{"user", "admin", "business_owner", "super_admin"}},
+	"gender":     {column: "gender", kind: kindText, ops: equalOps, options: []string{"male", "female"}},
+	"status":     {column: "status", kind: kindText, ops: equalOps, options: []string{"active", "blocked", "inverify"}},
+	"created_at": {column: "created_at", kind: kindTime, ops: compareOps, sortable: true},
+}
+
+var sessionListColumns = map[string]listColumn{
+	"platform":       {column: "platform", kind: kindText, ops: equalOps, options: []string{"web", "mobile", "admin_web"}},
+	"is_active":      {column: "is_active", kind: kindBool, ops: equalOps},
+	"expires_at":     {column: "expires_at", kind: kindTime, ops: compareOps, sortable: true},
+	"last_active_at": {column: "last_active_at", kind: kindTime, ops: compareOps, sortable: true},
+	"created_at":     {column: "created_at", kind: kindTime, ops: compareOps, sortable: true},
+}
+
+var businessListColumns = map[string]listColumn{
+	"name":           {column: "name", kind: kindText, ops: textOps, sortable: true},
+	"address":        {column: "address", kind: kindText, ops: textOps},
+	"owner_id":       {column: "owner_id", kind: kindUUID, ops: equalOps, nullable: true},
+	"avg_rating":     {column: "avg_rating", kind: kindNumber, ops: compareOps, sortable: true},
+	"review_count":   {column: "review_count", kind: kindInt, ops: compareOps, sortable: true},
+	"check_in_count": {column: "check_in_count", kind: kindInt, ops: compareOps, sortable: true},
+	"price_level":    {column: "price_level", kind: kindInt, ops: compareOps, nullable: true},
+	"created_at":     {column: "created_at", kind: kindTime, ops: compareOps, sortable: true},
+}
+
+var reviewListColumns = map[string]listColumn{
+	"business_id":  {column: "reviews.business_id", kind: kindUUID, ops: equalOps},
+	"user_id":      {column: "reviews.user_id", kind: kindUUID, ops: equalOps},
+	"rating":       {column: "reviews.rating", kind: kindInt, ops: compareOps, sortable: true},
+	"useful_count": {column: "reviews.useful_count", kind: kindInt, ops: compareOps, sortable: true},
+	"created_at":   {column: "reviews.created_at", kind: kindTime, ops: compareOps, sortable: true},
+}
+
+var filterParamPattern = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+// parseListQuery reads filter[column][op]=value and sort=-column,column parameters into req. An op left out means eq,
+// a leading - sorts descending. A sort replaces the default order of req, columns outside the whitelist are rejected.
+// The older sort_by parameter of a list can not be combined with sort.
+func parseListQuery(query url.Values, columns map[string]listColumn, req *entity.GetListFilter) error {
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		match := filterParamPattern.FindStringSubmatch(key)
+		if match == nil {
+			return fmt.Errorf("%s is not a valid filter, use filter[column][op]=value", key)
+		}
+
+		name, op := match[1], match[2]
+		if op == "" {
+			op = "eq"
+		}
+
+		column, ok := columns[name]
+		if !ok || len(column.ops) == 0 {
+			return fmt.Errorf("filtering by %s is not supported, use one of %s", name, strings.Join(filterableColumns(columns), ", "))
+		}
+		if !slices.Contains(column.ops, op) {
+			return fmt.Errorf("%s can only be filtered with %s", name, strings.Join(column.ops, ", "))
+		}
+
+		for _, value := range query[key] {
+			value, err := column.parse(value)
+			if err != nil {
+				return fmt.Errorf("filter[%s]: %w", name, err)
+			}
+
+			req.Filters = append(req.Filters, entity.Filter{Column: column.column, Type: op, Value: value})
+		}
+	}
+
+	sort := query.Get("sort")
+	if sort == "" {
+		return nil
+	}
+	if query.Get("sort_by") != "" {
+		return errors.New("use either sort or sort_by")
+	}
+
+	var orderBy []entity.OrderBy
+	for _, item := range strings.Split(sort, ",") {
+		order := "asc"
+		if name, ok := strings.CutPrefix(item, "-"); ok {
+			item, order = name, "desc"
+		}
+
+		column, ok := columns[item]
+		if !ok || !column.sortable || column.nullable {
+			return fmt.Errorf("sorting by %q is not supported, use one of %s", item, strings.Join(sortableColumns(columns), ", "))
+		}
+		if slices.ContainsFunc(orderBy, func(e entity.OrderBy) bool { return e.Column == column.column }) {
+			return fmt.Errorf("sort lists %s twice", item)
+		}
+
+		orderBy = append(orderBy, entity.OrderBy{Column: column.column, Order: order})
+	}
+	req.OrderBy = orderBy
+
+	return nil
+}
+
+// parse checks a filter value against the column kind and returns it the way the database reads it
+func (c listColumn) parse(value string) (string, error) {
+	if c.options != nil && !slices.Contains(c.options, value) {
+		return "", fmt.Errorf("must be one of %s", strings.Join(c.options, ", "))
+	}
+
+	switch c.kind {
+	case kindInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", errors.New("must be an integer")
+		}
+	case kindNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", errors.New("must be a number")
+		}
+	case kindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", errors.New("must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case kindUUID:
+		if _, err := uuid.Parse(value); err != nil {
+			return "", errors.New("must be a UUID")
+		}
+	case kindTime:
+		// timestamps are stored in UTC without a time zone
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return "", errors.New("must be an RFC3339 time")
+		}
+		return t.UTC().Format("2006-01-02 15:04:05.999999"), nil
+	case kindText:
+		if len(value) > config.MaxSearchQueryLength {
+			return "", fmt.Errorf("must be at most %d characters", config.MaxSearchQueryLength)
+		}
+	}
+
+	return value, nil
+}
+
+func filterableColumns(columns map[string]listColumn) []string {
+	var names []string
+	for name, column := range columns {
+		if len(column.ops) > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func sortableColumns(columns map[string]listColumn) []string {
+	var names []string
+	for name, column := range columns {
+		if column.sortable && !column.nullable {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
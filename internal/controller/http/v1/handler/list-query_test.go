@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+)
+
+func TestListColumnsSortable(t *testing.T) {
+	lists := map[string]map[string]listColumn{
+		"users":      userListColumns,
+		"sessions":   sessionListColumns,
+		"businesses": businessListColumns,
+		"reviews":    reviewListColumns,
+	}
+
+	for list, columns := range lists {
+		for name, column := range columns {
+			if column.sortable && column.nullable {
+				t.Errorf("%s: %s is sortable but may be NULL", list, name)
+			}
+		}
+	}
+}
+
+func TestParseListQuerySortNullable(t *testing.T) {
+	columns := map[string]listColumn{
+		"name":        {column: "name", kind: kindText, sortable: true},
+		"price_level": {column: "price_level", kind: kindInt, sortable: true, nullable: true},
+	}
+
+	var req entity.GetListFilter
+	err := parseListQuery(url.Values{"sort": {"-price_level"}}, columns, &req)
+	if err == nil {
+		t.Fatalf("parseListQuery() order = %v, want an error", req.OrderBy)
+	}
+
+	want := `sorting by "price_level" is not supported, use one of name`
+	if err.Error() != want {
+		t.Errorf("parseListQuery() error = %q, want %q", err, want)
+	}
+}
+
+func TestParseListQuery(t *testing.T) {
+	defaultOrder := []entity.OrderBy{{Column: "reviews.created_at", Order: "desc"}}
+
+	tests := []struct {
+		name        string
+		query       string
+		columns     map[string]listColumn
+		wantFilters []entity.Filter
+		wantOrder   []entity.OrderBy
+		wantErr     string
+	}{
+		{
+			name:      "no parameters keep the default order",
+			query:     "page=2&limit=5&search=pizza",
+			columns:   reviewListColumns,
+			wantOrder: defaultOrder,
+		},
+		{
+			name:        "op left out means eq",
+			query:       "filter[user_id]=7f1c0c5e-0000-4000-8000-000000000001",
+			columns:     reviewListColumns,
+			wantFilters: []entity.Filter{{Column: "reviews.user_id", Type: "eq", Value: "7f1c0c5e-0000-4000-8000-000000000001"}},
+			wantOrder:   defaultOrder,
+		},
+		{
+			name:    "filters in key order and every value",
+			query:   "filter[rating][neq]=1&filter[rating][neq]=2&filter[created_at][gte]=2024-05-01T15:00:00%2B05:00",
+			columns: reviewListColumns,
+			wantFilters: []entity.Filter{
+				{Column: "reviews.created_at", Type: "gte", Value: "2024-05-01 10:00:00"},
+				{Column: "reviews.rating", Type: "neq", Value: "1"},
+				{Column: "reviews.rating", Type: "neq", Value: "2"},
+			},
+			wantOrder: defaultOrder,
+		},
+		{
+			name:        "bool values are normalized",
+			query:       "filter[is_active]=1",
+			columns:     sessionListColumns,
+			wantFilters: []entity.Filter{{Column: "is_active", Type: "eq", Value: "true"}},
+			wantOrder:   defaultOrder,
+		},
+		{
+			name:        "text contains",
+			query:       "filter[full_name][contains]=aziz",
+			columns:     userListColumns,
+			wantFilters: []entity.Filter{{Column: "full_name", Type: "contains", Value: "aziz"}},
+			wantOrder:   defaultOrder,
+		},
+		{
+			name:    "unknown column",
+			query:   "filter[email]=a@b.c",
+			columns: reviewListColumns,
+			wantErr: "filtering by email is not supported, use one of business_id, created_at, rating, useful_count, user_id",
+		},
+		{
+			name:    "op the column does not allow",
+			query:   "filter[rating][contains]=4",
+			columns: reviewListColumns,
+			wantErr: "rating can only be filtered with eq, neq, gt, gte, lt, lte",
+		},
+		{
+			name:    "unknown op",
+			query:   "filter[rating][between]=4",
+			columns: reviewListColumns,
+			wantErr: "rating can only be filtered with eq, neq, gt, gte, lt, lte",
+		},
+		{
+			name:    "malformed key",
+			query:   "filter[Rating]=4",
+			columns: reviewListColumns,
+			wantErr: "filter[Rating] is not a valid filter, use filter[column][op]=value",
+		},
+		{
+			name:    "value of the wrong type",
+			query:   "filter[rating][gte]=four",
+			columns: reviewListColumns,
+			wantErr: "filter[rating]: must be an integer",
+		},
+		{
+			name:    "value outside the enum",
+			query:   "filter[status]=deleted",
+			columns: userListColumns,
+			wantErr: "filter[status]: must be one of active, blocked, inverify",
+		},
+		{
+			name:      "sort replaces the default order",
+			query:     "sort=-rating,created_at",
+			columns:   reviewListColumns,
+			wantOrder: []entity.OrderBy{{Column: "reviews.rating", Order: "desc"}, {Column: "reviews.created_at", Order: "asc"}},
+		},
+		{
+			name:    "sort by a column that is only filterable",
+			query:   "sort=business_id",
+			columns: reviewListColumns,
+			wantErr: `sorting by "business_id" is not supported, use one of created_at, rating, useful_count`,
+		},
+		{
+			name:    "sort by an unknown column",
+			query:   "sort=rating,",
+			columns: reviewListColumns,
+			wantErr: `sorting by "" is not supported, use one of created_at, rating, useful_count`,
+		},
+		{
+			name:    "sort by a column twice",
+			query:   "sort=rating,-rating",
+			columns: reviewListColumns,
+			wantErr: "sort lists rating twice",
+		},
+		{
+			name:      "sort_by alone is left to the list",
+			query:     "sort_by=helpful",
+			columns:   reviewListColumns,
+			wantOrder: defaultOrder,
+		},
+		{
+			name:    "sort and sort_by",
+			query:   "sort=-rating&sort_by=helpful",
+			columns: reviewListColumns,
+			wantErr: "use either sort or sort_by",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+
+			req := entity.GetListFilter{OrderBy: defaultOrder}
+			err = parseListQuery(query, tt.columns, &req)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("parseListQuery() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseListQuery() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(req.Filters, tt.wantFilters) {
+				t.Errorf("filters = %v, want %v", req.Filters, tt.wantFilters)
+			}
+			if !reflect.DeepEqual(req.OrderBy, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", req.OrderBy, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestListColumnParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  listColumn
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "int", column: listColumn{kind: kindInt}, value: "42", want: "42"},
+		{name: "negative int", column: listColumn{kind: kindInt}, value: "-3", want: "-3"},
+		{name: "int with a fraction", column: listColumn{kind: kindInt}, value: "4.5", wantErr: "must be an integer"},
+		{name: "number", column: listColumn{kind: kindNumber}, value: "4.5", want: "4.5"},
+		{name: "not a number", column: listColumn{kind: kindNumber}, value: "high", wantErr: "must be a number"},
+		{name: "bool", column: listColumn{kind: kindBool}, value: "TRUE", want: "true"},
+		{name: "bool as a digit", column: listColumn{kind: kindBool}, value: "0", want: "false"},
+		{name: "not a bool", column: listColumn{kind: kindBool}, value: "yes", wantErr: "must be true or false"},
+		{
+			name:   "uuid",
+			column: listColumn{kind: kindUUID},
+			value:  "7f1c0c5e-0000-4000-8000-000000000001",
+			want:   "7f1c0c5e-0000-4000-8000-000000000001",
+		},
+		{name: "not a uuid", column: listColumn{kind: kindUUID}, value: "1", wantErr: "must be a UUID"},
+		{name: "time in UTC", column: listColumn{kind: kindTime}, value: "2024-05-01T10:00:00Z", want: "2024-05-01 10:00:00"},
+		{
+			name:   "time in another zone",
+			column: listColumn{kind: kindTime},
+			value:  "2024-05-01T10:00:00.25-02:00",
+			want:   "2024-05-01 12:00:00.25",
+		},
+		{name: "date without a time", column: listColumn{kind: kindTime}, value: "2024-05-01", wantErr: "must be an RFC3339 time"},
+		{name: "text", column: listColumn{kind: kindText}, value: "pizza", want: "pizza"},
+		{
+			name:    "text too long",
+			column:  listColumn{kind: kindText},
+			value:   strings.Repeat("a", config.MaxSearchQueryLength+1),
+			wantErr: "must be at most 200 characters",
+		},
+		{name: "enum", column: listColumn{kind: kindText, options: []string{"web", "mobile"}}, value: "web", want: "web"},
+		{
+			name:    "outside the enum",
+			column:  listColumn{kind: kindText, options: []string{"web", "mobile"}},
+			value:   "WEB",
+			wantErr: "must be one of web, mobile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.column.parse(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("parse(%q) = %q, %v, want error %q", tt.value, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
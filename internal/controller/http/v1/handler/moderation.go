@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+var reviewStatuses = map[string]bool{
+	config.ReviewStatusPending:   true,
+	config.ReviewStatusPublished: true,
+	config.ReviewStatusHidden:    true,
+	config.ReviewStatusRemoved:   true,
+}
+
+// GetModerationReviews godoc
+// @Router /moderation/reviews [get]
+// @Summary Review moderation queue
+// @Description Reviews waiting for a moderator, the most reported first
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param page query number false "page"
+// @Param limit query number false "limit"
+// @Param status query string false "review status, pending by default" Enums(pending, published, hidden, removed)
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, page is ignored when set"
+// @Param with_count query boolean false "include the total count, on by default without a cursor"
+// @Param filter query string false "filter[column][op]=value, op is eq (default), neq, gt, gte, lt, lte or contains. Columns: business_id, user_id, rating, useful_count, created_at"
+// @Param sort query string false "comma separated columns, a leading - sorts descending. Columns: rating, useful_count, created_at"
+// @Success 200 {object} entity.ReviewList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetModerationReviews(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	status := ctx.DefaultQuery("status", config.ReviewStatusPending)
+	if !reviewStatuses[status] {
+		h.ReturnError(ctx, config.ErrorBadRequest, "status must be one of pending, published, hidden, removed", http.StatusBadRequest)
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "reviews.status",
+		Type:   "eq",
+		Value:  status,
+	})
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{Column: "reviews.report_count", Order: "desc"},
+		entity.OrderBy{Column: "reviews.created_at", Order: "asc"},
+	)
+
+	if err := parseListQuery(ctx.Request.URL.Query(), reviewListColumns, &req); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.pageRequest(ctx, &req) {
+		return
+	}
+
+	reviews, err := h.UseCase.ReviewRepo.GetList(ctx, entity.ReviewListFilter{GetListFilter: req, WithHidden: true})
+	if h.HandleDbError(ctx, err, "Error getting reviews") {
+		return
+	}
+
+	h.signPage(&reviews.Page)
+
+	ctx.JSON(200, reviews)
+}
+
+// GetModerationReviewReports godoc
+// @Router /moderation/reviews/{id}/reports [get]
+// @Summary Reports of a review
+// @Description Reports of a review, open ones first
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param page query number false "page"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.ReviewReportList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetModerationReviewReports(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "review_id",
+		Type:   "eq",
+		Value:  ctx.Param("id"),
+	})
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{Column: "resolved", Order: "asc"},
+		entity.OrderBy{Column: "created_at", Order: "desc"},
+	)
+
+	reports, err := h.UseCase.ReviewReportRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting review reports") {
+		return
+	}
+
+	ctx.JSON(200, reports)
+}
+
+// ApproveReview godoc
+// @Router /moderation/reviews/{id}/approve [post]
+// @Summary Approve a review
+// @Description Publishes the review and resolves its reports
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ApproveReview(ctx *gin.Context) {
+	h.moderateReview(ctx, config.ReviewStatusPublished)
+}
+
+// HideReview godoc
+// @Router /moderation/reviews/{id}/hide [post]
+// @Summary Hide a review
+// @Description Hides the review from everyone but its author and moderators, and resolves its reports
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) HideReview(ctx *gin.Context) {
+	h.moderateReview(ctx, config.ReviewStatusHidden)
+}
+
+// RemoveReview godoc
+// @Router /moderation/reviews/{id}/remove [post]
+// @Summary Remove a review
+// @Description Removes the review for violating the content policy, and resolves its reports
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RemoveReview(ctx *gin.Context) {
+	h.moderateReview(ctx, config.ReviewStatusRemoved)
+}
+
+// moderateReview sets the status decided by the moderator and closes the open reports of the review
+func (h *Handler) moderateReview(ctx *gin.Context, status string) {
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	_, err = h.UseCase.ReviewRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: review.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "status", Value: status},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error moderating review") {
+		return
+	}
+
+	_, err = h.UseCase.ReviewReportRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "review_id", Type: "eq", Value: review.ID},
+			{Column: "resolved", Type: "eq", Value: "false"},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "resolved", Value: "true"},
+			{Column: "updated_at", Value: "now()"},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error resolving review reports") {
+		return
+	}
+
+	review, err = h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: review.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	ctx.JSON(200, review)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/pkg/etc"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// issueOtp stores a new code under key and resets its failed attempts counter
+func (h *Handler) issueOtp(ctx *gin.Context, key string) (string, error) {
+	otp, err := etc.GenerateOTP(config.OtpLength)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = h.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, otp, config.OtpExpireTime)
+		pipe.Del(ctx, key+"-attempts")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return otp, nil
+}
+
+// otpCooldown starts the resend cooldown of key, it returns false if the previous one is still running
+func (h *Handler) otpCooldown(ctx *gin.Context, key string) (bool, error) {
+	// SET NX decides between concurrent resends, only one of them starts the cooldown
+	return h.RedisClient.SetNX(ctx, key+"-cooldown", "1", config.OtpResendCooldown).Result()
+}
+
+// verifyOtp checks code against the one stored under key. Codes are single use and the key is
+// locked after config.OtpMaxAttempts wrong guesses. It writes the error response itself and
+// returns false if the code was not accepted.
+func (h *Handler) verifyOtp(ctx *gin.Context, key, code string) bool {
+	locked, err := h.RedisClient.Exists(ctx, key+"-lock").Result()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Ooops, something went wrong", http.StatusInternalServerError)
+		return false
+	}
+	if locked > 0 {
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Too many attempts, please try again later", http.StatusTooManyRequests)
+		return false
+	}
+
+	// every guess is counted before it is compared, so concurrent guesses cannot get past the limit
+	var attempts *redis.IntCmd
+	_, err = h.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		attempts = pipe.Incr(ctx, key+"-attempts")
+		pipe.Expire(ctx, key+"-attempts", config.OtpExpireTime+time.Minute)
+		return nil
+	})
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Ooops, something went wrong", http.StatusInternalServerError)
+		return false
+	}
+
+	if attempts.Val() > int64(config.OtpMaxAttempts) {
+		h.lockOtp(ctx, key)
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Too many attempts, please try again later", http.StatusTooManyRequests)
+		return false
+	}
+
+	otp, err := h.RedisClient.Get(ctx, key).Result()
+	if err == redis.Nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Otp is expired", http.StatusBadRequest)
+		return false
+	}
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Ooops, something went wrong", http.StatusInternalServerError)
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(otp), []byte(code)) == 1 {
+		// of concurrent requests with the right code only the one deleting it is accepted
+		deleted, err := h.RedisClient.Del(ctx, key).Result()
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorInternalServer, "Ooops, something went wrong", http.StatusInternalServerError)
+			return false
+		}
+		if deleted == 0 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Otp is expired", http.StatusBadRequest)
+			return false
+		}
+
+		h.clearOtp(ctx, key)
+		return true
+	}
+
+	if attempts.Val() >= int64(config.OtpMaxAttempts) {
+		h.lockOtp(ctx, key)
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Too many attempts, please try again later", http.StatusTooManyRequests)
+		return false
+	}
+
+	h.ReturnError(ctx, config.ErrorBadRequest, "Incorrect otp", http.StatusBadRequest)
+	return false
+}
+
+// lockOtp rejects further guesses for key until config.OtpLockoutTime passes and drops its code
+func (h *Handler) lockOtp(ctx *gin.Context, key string) {
+	err := h.RedisClient.Set(ctx, key+"-lock", "1", config.OtpLockoutTime).Err()
+	if err != nil {
+		h.Logger.Error(err, "Error locking OTP")
+	}
+
+	h.clearOtp(ctx, key)
+}
+
+func (h *Handler) clearOtp(ctx *gin.Context, key string) {
+	err := h.RedisClient.Del(ctx, key, key+"-attempts").Err()
+	if err != nil {
+		h.Logger.Error(err, "Error deleting OTP")
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateReviewReply godoc
+// @Router /review/{id}/reply [post]
+// @Summary Reply to a review
+// @Description The owner of the reviewed business answers the review, one reply per review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param reply body entity.ReviewReplyRequest true "Reply"
+// @Success 201 {object} entity.ReviewReply
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) CreateReviewReply(ctx *gin.Context) {
+	var (
+		body entity.ReviewReplyRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Comment == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	review, ok := h.ownedReview(ctx)
+	if !ok {
+		return
+	}
+
+	reply, err := h.UseCase.ReviewReplyRepo.Create(ctx, entity.ReviewReply{
+		ReviewID: review.ID,
+		UserID:   ctx.GetHeader("sub"),
+		Comment:  body.Comment,
+	})
+	if h.HandleDbError(ctx, err, "Error creating review reply") {
+		return
+	}
+
+	ctx.JSON(201, reply)
+}
+
+// UpdateReviewReply godoc
+// @Router /review/{id}/reply [put]
+// @Summary Update the reply to a review
+// @Description Update the reply to a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param reply body entity.ReviewReplyRequest true "Reply"
+// @Success 200 {object} entity.ReviewReply
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) UpdateReviewReply(ctx *gin.Context) {
+	var (
+		body entity.ReviewReplyRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Comment == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	review, ok := h.ownedReview(ctx)
+	if !ok {
+		return
+	}
+
+	reply, err := h.UseCase.ReviewReplyRepo.GetSingle(ctx, entity.ReviewReplySingleRequest{ReviewID: review.ID})
+	if h.HandleDbError(ctx, err, "Error getting review reply") {
+		return
+	}
+
+	reply.Comment = body.Comment
+
+	reply, err = h.UseCase.ReviewReplyRepo.Update(ctx, reply)
+	if h.HandleDbError(ctx, err, "Error updating review reply") {
+		return
+	}
+
+	ctx.JSON(200, reply)
+}
+
+// DeleteReviewReply godoc
+// @Router /review/{id}/reply [delete]
+// @Summary Delete the reply to a review
+// @Description Delete the reply to a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) DeleteReviewReply(ctx *gin.Context) {
+	review, ok := h.ownedReview(ctx)
+	if !ok {
+		return
+	}
+
+	reply, err := h.UseCase.ReviewReplyRepo.GetSingle(ctx, entity.ReviewReplySingleRequest{ReviewID: review.ID})
+	if h.HandleDbError(ctx, err, "Error getting review reply") {
+		return
+	}
+
+	err = h.UseCase.ReviewReplyRepo.Delete(ctx, entity.Id{ID: reply.ID})
+	if h.HandleDbError(ctx, err, "Error deleting review reply") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Review reply deleted successfully",
+	})
+}
+
+// ownedReview loads the review of the path and checks that the caller owns the reviewed business
+func (h *Handler) ownedReview(ctx *gin.Context) (entity.Review, bool) {
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return entity.Review{}, false
+	}
+
+	if !reviewVisible(ctx, review) {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return entity.Review{}, false
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: review.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return entity.Review{}, false
+	}
+
+	if business.OwnerID != ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only the owner of the business can reply to its reviews", http.StatusForbidden)
+		return entity.Review{}, false
+	}
+
+	return review, true
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+var reviewVoteTypes = map[string]bool{
+	"useful": true,
+	"funny":  true,
+	"cool":   true,
+}
+
+// VoteReview godoc
+// @Router /review/{id}/vote [post]
+// @Summary Vote on a review
+// @Description Votes a published review as useful, funny or cool. A user has one vote per review: another type replaces it, the same type takes it back.
+// @Description Authors can not vote on their own reviews
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param vote body entity.ReviewVoteRequest true "Vote"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) VoteReview(ctx *gin.Context) {
+	var (
+		body entity.ReviewVoteRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || !reviewVoteTypes[body.Type] {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Vote type must be one of useful, funny, cool", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if !reviewVisible(ctx, review) {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
+	if review.Status != config.ReviewStatusPublished {
+		h.ReturnError(ctx, config.ErrorConflict, "Only published reviews can be voted on", http.StatusConflict)
+		return
+	}
+	if review.UserID == ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can not vote on your own review", http.StatusForbidden)
+		return
+	}
+
+	req := entity.ReviewVoteSingleRequest{ReviewID: review.ID, UserID: ctx.GetHeader("sub")}
+
+	vote, err := h.UseCase.ReviewVoteRepo.GetSingle(ctx, req)
+	switch {
+	case err == pgx.ErrNoRows:
+		_, err = h.UseCase.ReviewVoteRepo.Create(ctx, entity.ReviewVote{ReviewID: req.ReviewID, UserID: req.UserID, Type: body.Type})
+	case err != nil:
+	case vote.Type == body.Type:
+		err = h.UseCase.ReviewVoteRepo.Delete(ctx, req)
+	default:
+		vote.Type = body.Type
+		_, err = h.UseCase.ReviewVoteRepo.Update(ctx, vote)
+	}
+	if h.HandleDbError(ctx, err, "Error voting on review") {
+		return
+	}
+
+	review, err = h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: review.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	reviews := []entity.Review{review}
+	err = h.setMyVotes(ctx, reviews)
+	if h.HandleDbError(ctx, err, "Error getting review votes") {
+		return
+	}
+
+	ctx.JSON(200, reviews[0])
+}
+
+// setMyVotes fills in the caller's own vote on each review
+func (h *Handler) setMyVotes(ctx *gin.Context, reviews []entity.Review) error {
+	req := entity.ReviewUserVotesRequest{UserID: ctx.GetHeader("sub")}
+	for _, review := range reviews {
+		req.ReviewIDs = append(req.ReviewIDs, review.ID)
+	}
+
+	votes, err := h.UseCase.ReviewVoteRepo.GetUserVotes(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for i := range reviews {
+		reviews[i].MyVote = votes[reviews[i].ID]
+	}
+
+	return nil
+}
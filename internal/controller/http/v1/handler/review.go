@@ -1,23 +1,29 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/abdulazizax/yelp/config"
 	"github.com/abdulazizax/yelp/internal/entity"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
 )
 
 // CreateReview godoc
 // @Router /review [post]
 // @Summary Create a new review
-// @Description Create a new review
+// @Description Create a new review. A user has one review per business, posting again updates it.
+// @Description Attachments are added through POST /review/{id}/attachment, the body only keeps uploaded ones by id
 // @Security BearerAuth
 // @Tags review
 // @Accept  json
 // @Produce  json
 // @Param review body entity.Review true "Review object"
+// @Success 200 {object} entity.Review
 // @Success 201 {object} entity.Review
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) CreateReview(ctx *gin.Context) {
@@ -31,8 +37,30 @@ func (h *Handler) CreateReview(ctx *gin.Context) {
 		return
 	}
 
+	if slices.ContainsFunc(body.Attachments, func(a entity.ReviewAttachment) bool { return a.Id == "" }) {
+		h.ReturnError(ctx, config.ErrorBadRequest, errAttachmentNotUploaded.Error(), 400)
+		return
+	}
+
 	body.UserID = ctx.GetHeader("sub")
 
+	existing, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{
+		BusinessID: body.BusinessID,
+		UserID:     body.UserID,
+	})
+	if err == nil {
+		body.ID = existing.ID
+		h.updateReview(ctx, body)
+		return
+	}
+	if err != pgx.ErrNoRows && h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if !h.screenReview(ctx, &body) {
+		return
+	}
+
 	review, err := h.UseCase.ReviewRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating review") {
 		return
@@ -61,18 +89,23 @@ func (h *Handler) CreateReview(ctx *gin.Context) {
 // @Success 200 {object} entity.Review
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetReview(ctx *gin.Context) {
-	var (
-		req entity.Id
-	)
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
 
-	req.ID = ctx.Param("id")
+	if !reviewVisible(ctx, review) {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
 
-	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, req)
-	if h.HandleDbError(ctx, err, "Error getting review") {
+	reviews := []entity.Review{review}
+	err = h.setMyVotes(ctx, reviews)
+	if h.HandleDbError(ctx, err, "Error getting review votes") {
 		return
 	}
 
-	ctx.JSON(200, review)
+	ctx.JSON(200, reviews[0])
 }
 
 // GetReviews godoc
@@ -86,6 +119,12 @@ func (h *Handler) GetReview(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param sort_by query string false "sort column, helpful orders by useful votes" Enums(created_at, helpful)
+// @Param order query string false "sort order" Enums(asc, desc)
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, page is ignored when set"
+// @Param with_count query boolean false "include the total count, on by default without a cursor"
+// @Param filter query string false "filter[column][op]=value, op is eq (default), neq, gt, gte, lt, lte or contains. Columns: business_id, user_id, rating, useful_count, created_at"
+// @Param sort query string false "comma separated columns, a leading - sorts descending. Columns: rating, useful_count, created_at"
 // @Success 200 {object} entity.ReviewList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetReviews(ctx *gin.Context) {
@@ -101,34 +140,82 @@ func (h *Handler) GetReviews(ctx *gin.Context) {
 	req.Limit, _ = strconv.Atoi(limit)
 	req.Filters = append(req.Filters,
 		entity.Filter{
-			Column: "rating",
-			Type:   "search",
-			Value:  search,
-		},
-		entity.Filter{
-			Column: "comment",
-			Type:   "search",
+			Column: "reviews.search_vector",
+			Type:   "fts",
 			Value:  search,
 		},
 	)
 
-	req.OrderBy = append(req.OrderBy, entity.OrderBy{
-		Column: "created_at",
-		Order:  "desc",
+	orderBy, err := parseReviewOrderBy(ctx.DefaultQuery("sort_by", "created_at"), ctx.DefaultQuery("order", "desc"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.OrderBy = append(req.OrderBy, orderBy...)
+
+	if err := parseListQuery(ctx.Request.URL.Query(), reviewListColumns, &req); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.pageRequest(ctx, &req) {
+		return
+	}
+
+	// reviews that are not published are only listed to their author and moderators
+	reviews, err := h.UseCase.ReviewRepo.GetList(ctx, entity.ReviewListFilter{
+		GetListFilter: req,
+		ViewerID:      ctx.GetHeader("sub"),
+		WithHidden:    ctx.GetHeader("user_type") == "admin",
 	})
+	if h.HandleDbError(ctx, err, "Error getting reviews") {
+		return
+	}
 
-	users, err := h.UseCase.ReviewRepo.GetList(ctx, req)
-	if h.HandleDbError(ctx, err, "Error getting users") {
+	h.signPage(&reviews.Page)
+
+	err = h.setMyVotes(ctx, reviews.Items)
+	if h.HandleDbError(ctx, err, "Error getting review votes") {
 		return
 	}
 
-	ctx.JSON(200, users)
+	ctx.JSON(200, reviews)
+}
+
+// reviewVisible tells if the caller may see the review, reviews held for moderation, hidden or removed are
+// left to their author and moderators
+func reviewVisible(ctx *gin.Context, review entity.Review) bool {
+	if review.Status == config.ReviewStatusPublished {
+		return true
+	}
+
+	sub := ctx.GetHeader("sub")
+	return (sub != "" && review.UserID == sub) || ctx.GetHeader("user_type") == "admin"
+}
+
+// parseReviewOrderBy maps sort_by to review columns, helpful ties are broken by the newest review
+func parseReviewOrderBy(sortBy, order string) ([]entity.OrderBy, error) {
+	if order != "asc" && order != "desc" {
+		return nil, errors.New("order must be asc or desc")
+	}
+
+	switch sortBy {
+	case "created_at":
+		return []entity.OrderBy{{Column: "reviews.created_at", Order: order}}, nil
+	case "helpful":
+		return []entity.OrderBy{
+			{Column: "reviews.useful_count", Order: order},
+			{Column: "reviews.created_at", Order: "desc"},
+		}, nil
+	default:
+		return nil, errors.New("sort_by must be one of created_at, helpful")
+	}
 }
 
 // UpdateReview godoc
 // @Router /review [put]
 // @Summary Update a review
-// @Description Update a review
+// @Description Update a review, attachments left out of the body are deleted and new ones are rejected
 // @Security BearerAuth
 // @Tags review
 // @Accept  json
@@ -147,16 +234,41 @@ func (h *Handler) UpdateReview(ctx *gin.Context) {
 		return
 	}
 
-	if body.UserID != ctx.GetHeader("sub") || ctx.GetHeader("user_type") != "admin" {
+	if slices.ContainsFunc(body.Attachments, func(a entity.ReviewAttachment) bool { return a.Id == "" }) {
+		h.ReturnError(ctx, config.ErrorBadRequest, errAttachmentNotUploaded.Error(), 400)
+		return
+	}
+
+	h.updateReview(ctx, body)
+}
+
+// updateReview changes the rating and comment of a stored review, the previous version goes to its history
+func (h *Handler) updateReview(ctx *gin.Context, body entity.Review) {
+	existing, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if existing.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
 		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the comment", http.StatusForbidden)
 		return
 	}
 
-	review, err := h.UseCase.ReviewRepo.Update(ctx, body)
+	body.UserID = existing.UserID
+	if !h.screenReview(ctx, &body) {
+		return
+	}
+
+	_, err = h.UseCase.ReviewRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating review") {
 		return
 	}
 
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
 	review.Attachments, err = h.UseCase.ReviewAttachmentRepo.MultipleUpsert(ctx, entity.ReviewAttachmentMultipleInsertRequest{
 		ReviewId:    review.ID,
 		Attachments: body.Attachments,
@@ -186,12 +298,12 @@ func (h *Handler) DeleteReview(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	body, err := h.UseCase.ReviewRepo.GetSingle(ctx, req)
+	body, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: req.ID})
 	if h.HandleDbError(ctx, err, "Error getting review") {
 		return
 	}
 
-	if body.UserID != ctx.GetHeader("sub") || ctx.GetHeader("user_type") != "admin" {
+	if body.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
 		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the comment", http.StatusForbidden)
 		return
 	}
@@ -205,3 +317,158 @@ func (h *Handler) DeleteReview(ctx *gin.Context) {
 		Message: "Review deleted successfully",
 	})
 }
+
+// UploadReviewAttachment godoc
+// @Router /review/{id}/attachment [post]
+// @Summary Upload a review photo or video
+// @Description Upload a review photo or video
+// @Security BearerAuth
+// @Tags review
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param file formData file true "Photo or video"
+// @Success 201 {object} entity.ReviewAttachment
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UploadReviewAttachment(ctx *gin.Context) {
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if review.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the comment", http.StatusForbidden)
+		return
+	}
+
+	file, ok := h.storeUploadedFile(ctx, "reviews/"+review.ID)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.UseCase.ReviewAttachmentRepo.Create(ctx, entity.ReviewAttachment{
+		ReviewId:    review.ID,
+		FilePath:    file.FilePath,
+		ContentType: file.ContentType,
+		Variants:    file.Variants,
+	})
+	if h.HandleDbError(ctx, err, "Error creating review attachment") {
+		h.removeStoredFile(ctx, file)
+		return
+	}
+
+	ctx.JSON(201, attachment)
+}
+
+// GetReviewHistory godoc
+// @Router /review/{id}/history [get]
+// @Summary Get the edit history of a review
+// @Description Previous versions of the review, newest first. Visible to its author and admins
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param page query number false "page"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.ReviewRevisionList
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetReviewHistory(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if review.UserID != ctx.GetHeader("sub") && ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the comment", http.StatusForbidden)
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "review_id",
+		Type:   "eq",
+		Value:  review.ID,
+	})
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "replaced_at",
+		Order:  "desc",
+	})
+
+	revisions, err := h.UseCase.ReviewRevisionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting review history") {
+		return
+	}
+
+	ctx.JSON(200, revisions)
+}
+
+// ReportReview godoc
+// @Router /review/{id}/report [post]
+// @Summary Report a review
+// @Description Flags a review for moderation. A published review stays published until enough distinct users report it, then it goes back to pending until a moderator decides
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param report body entity.ReviewReportRequest true "Reason"
+// @Success 201 {object} entity.ReviewReport
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ReportReview(ctx *gin.Context) {
+	var (
+		body entity.ReviewReportRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || strings.TrimSpace(body.Reason) == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Reason is required", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.ReviewSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	if !reviewVisible(ctx, review) {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
+
+	if review.UserID == ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorBadRequest, "You cannot report your own review", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.UseCase.ReviewReportRepo.Create(ctx, entity.ReviewReport{
+		ReviewID: review.ID,
+		UserID:   ctx.GetHeader("sub"),
+		Reason:   strings.TrimSpace(body.Reason),
+	})
+	if h.HandleDbError(ctx, err, "Error reporting review") {
+		return
+	}
+
+	// a single reporter, e.g. the owner of the business, must not be able to take a review out of the rating
+	_, err = h.UseCase.ReviewRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "id", Type: "eq", Value: review.ID},
+			{Column: "status", Type: "eq", Value: config.ReviewStatusPublished},
+			{Column: "report_count", Type: "gte", Value: strconv.Itoa(h.Config.Moderation.ReportThreshold)},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "status", Value: config.ReviewStatusPending},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error queueing review for moderation") {
+		return
+	}
+
+	ctx.JSON(201, report)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+func TestReviewVisible(t *testing.T) {
+	const author = "7f1c0c5e-0000-4000-8000-000000000001"
+
+	tests := []struct {
+		name     string
+		status   string
+		sub      string
+		userType string
+		want     bool
+	}{
+		{name: "published to anyone", status: config.ReviewStatusPublished, want: true},
+		{name: "published to another user", status: config.ReviewStatusPublished, sub: "other", userType: "user", want: true},
+		{name: "pending to another user", status: config.ReviewStatusPending, sub: "other", userType: "user"},
+		{name: "pending to anyone", status: config.ReviewStatusPending},
+		{name: "pending to its author", status: config.ReviewStatusPending, sub: author, userType: "user", want: true},
+		{name: "pending to an admin", status: config.ReviewStatusPending, sub: "admin", userType: "admin", want: true},
+		{name: "hidden to another user", status: config.ReviewStatusHidden, sub: "other", userType: "user"},
+		{name: "hidden to its author", status: config.ReviewStatusHidden, sub: author, userType: "user", want: true},
+		{name: "removed to another user", status: config.ReviewStatusRemoved, sub: "other", userType: "user"},
+		{name: "removed to an admin", status: config.ReviewStatusRemoved, sub: "admin", userType: "admin", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("GET", "/", nil)
+			if tt.sub != "" {
+				ctx.Request.Header.Set("sub", tt.sub)
+				ctx.Request.Header.Set("user_type", tt.userType)
+			}
+
+			got := reviewVisible(ctx, entity.Review{UserID: author, Status: tt.status})
+			if got != tt.want {
+				t.Errorf("reviewVisible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/internal/usecase"
+	"github.com/abdulazizax/yelp/pkg/screening"
+	"github.com/gin-gonic/gin"
+)
+
+// duplicateMinWords keeps short texts like "Great place!" from counting as duplicates
+const duplicateMinWords = 5
+
+func newReviewScreener(c *config.Config, useCase *usecase.UseCase) *screening.Pipeline {
+	history := reviewHistory{repo: useCase.ReviewRepo}
+
+	checkers := []screening.Checker{
+		screening.NewRate(history, c.Screening.RateWindow, c.Screening.RateLimit),
+		screening.NewWordList(c.Screening.BlockedWords, screening.Reject, screening.ReasonBlockedWord),
+		screening.NewDuplicate(history, c.Screening.DuplicateWindow, duplicateMinWords),
+		screening.NewWordList(c.Screening.FlaggedWords, screening.Hold, screening.ReasonFlaggedWord),
+	}
+	if c.Screening.HoldLinks {
+		checkers = append(checkers, screening.NewLinks(screening.Hold))
+	}
+	if c.Screening.HoldPhones {
+		checkers = append(checkers, screening.NewPhoneNumbers(screening.Hold))
+	}
+
+	return screening.New(checkers...)
+}
+
+// reviewHistory gives the screening pipeline the reviews a user wrote recently
+type reviewHistory struct {
+	repo usecase.ReviewRepoI
+}
+
+func (r reviewHistory) Recent(ctx context.Context, userID string, window time.Duration) ([]screening.Posted, error) {
+	// hidden and removed reviews still count towards the rate limit and duplicates
+	reviews, err := r.repo.GetRecent(ctx, entity.RecentReviewsRequest{
+		UserID: userID,
+		Window: window,
+		Limit:  100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]screening.Posted, 0, len(reviews))
+	for _, review := range reviews {
+		posts = append(posts, screening.Posted{ID: review.ID, Text: review.Comment})
+	}
+
+	return posts, nil
+}
+
+// screenReview rejects the review or marks it for moderation, the moderation fields of the request body are never trusted
+func (h *Handler) screenReview(ctx *gin.Context, review *entity.Review) bool {
+	review.Status = ""
+	review.ModerationReason = ""
+
+	result, err := h.Screener.Screen(ctx, screening.Content{
+		UserID: review.UserID,
+		ID:     review.ID,
+		Text:   review.Comment,
+	})
+	if err != nil {
+		h.Logger.Error(err, "Error screening review")
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return false
+	}
+
+	switch result.Verdict {
+	case screening.Reject:
+		h.ReturnError(ctx, config.ErrorContentRejected, "Review rejected: "+result.Reason, http.StatusBadRequest)
+		return false
+	case screening.Hold:
+		review.Status = config.ReviewStatusPending
+		review.ModerationReason = result.Reason
+	}
+
+	return true
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/etc"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// Search godoc
+// @Router /search [get]
+// @Summary Search businesses and reviews
+// @Description Full-text search ranked by relevance, business names and addresses also match misspelled queries.
+// @Description Snippets are HTML escaped and mark the matched words with <mark></mark>.
+// @Security BearerAuth
+// @Tags search
+// @Accept  json
+// @Produce  json
+// @Param q query string true "search query, supports \"quoted phrases\", or and -excluded words"
+// @Param type query string false "limit the results to one type" Enums(business, review)
+// @Param page query number false "page"
+// @Param limit query number false "limit, at most 50"
+// @Success 200 {object} entity.SearchResultList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) Search(ctx *gin.Context) {
+	var (
+		req entity.SearchRequest
+	)
+
+	req.Query = strings.TrimSpace(ctx.DefaultQuery("q", ""))
+	if req.Query == "" || utf8.RuneCountInString(req.Query) > config.MaxSearchQueryLength {
+		h.ReturnError(ctx, config.ErrorBadRequest, fmt.Sprintf("q is required and can be at most %d characters", config.MaxSearchQueryLength), http.StatusBadRequest)
+		return
+	}
+
+	switch ctx.DefaultQuery("type", "") {
+	case "":
+		req.Businesses, req.Reviews = true, true
+	case config.SearchTypeBusiness:
+		req.Businesses = true
+	case config.SearchTypeReview:
+		req.Reviews = true
+	default:
+		h.ReturnError(ctx, config.ErrorBadRequest, "type must be business or review", http.StatusBadRequest)
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(config.SearchDefaultLimit)))
+	req.Limit = clampSearchLimit(req.Limit)
+
+	results, err := h.UseCase.SearchRepo.Search(ctx, req)
+	if h.HandleDbError(ctx, err, "Error searching") {
+		return
+	}
+
+	// searches that found something become suggestions, later pages are not counted again
+	if results.Count > 0 && req.Page <= 1 && h.searchRecordAllowed(ctx) {
+		err := h.UseCase.SearchRepo.RecordQuery(ctx, entity.SearchQueryRecord{Query: req.Query, UserID: ctx.GetHeader("sub")})
+		if err != nil {
+			h.Logger.Error(fmt.Errorf("handler - Search - RecordQuery: %w", err))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+// searchRecordAllowed counts the searches of the caller recorded for suggestions, past config.SearchRecordLimit
+// within config.SearchRecordWindow the search still runs but is no longer recorded
+func (h *Handler) searchRecordAllowed(ctx *gin.Context) bool {
+	userID := ctx.GetHeader("sub")
+	if userID == "" {
+		return false
+	}
+
+	key := "search-record:" + userID
+
+	var count *redis.IntCmd
+	_, err := h.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// the window starts with the first search, later ones keep its expiry
+		pipe.SetNX(ctx, key, 0, config.SearchRecordWindow)
+		count = pipe.Incr(ctx, key)
+		return nil
+	})
+	if err != nil {
+		h.Logger.Error(fmt.Errorf("handler - Search - searchRecordAllowed: %w", err))
+		return false
+	}
+
+	return count.Val() <= int64(config.SearchRecordLimit)
+}
+
+// clampSearchLimit keeps the page size of a search between 1 and config.SearchMaxLimit, a missing limit gets the default
+func clampSearchLimit(limit int) int {
+	if limit < 1 {
+		return config.SearchDefaultLimit
+	}
+
+	return min(limit, config.SearchMaxLimit)
+}
+
+// Suggest godoc
+// @Router /search/suggest [get]
+// @Summary As-you-type search suggestions
+// @Description Business names, category names and popular searches completing the typed text.
+// @Description Nearby businesses rank higher when lat and lng are given.
+// @Security BearerAuth
+// @Tags search
+// @Accept  json
+// @Produce  json
+// @Param q query string true "typed text"
+// @Param lat query number false "latitude of the caller"
+// @Param lng query number false "longitude of the caller"
+// @Success 200 {object} entity.SuggestionList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) Suggest(ctx *gin.Context) {
+	var (
+		req = entity.SuggestRequest{Limit: config.SuggestLimit}
+	)
+
+	req.Query = strings.TrimSpace(ctx.DefaultQuery("q", ""))
+	if req.Query == "" || utf8.RuneCountInString(req.Query) > config.MaxSearchQueryLength {
+		h.ReturnError(ctx, config.ErrorBadRequest, fmt.Sprintf("q is required and can be at most %d characters", config.MaxSearchQueryLength), http.StatusBadRequest)
+		return
+	}
+
+	lat, lng := ctx.DefaultQuery("lat", ""), ctx.DefaultQuery("lng", "")
+	if lat != "" || lng != "" {
+		geo, err := parseGeoFilter(lat, lng, "")
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Geo = &geo
+	}
+
+	key := suggestCacheKey(h.Redis.Hash, req)
+	if cached, err := h.Redis.Get(ctx, key); err == nil {
+		var suggestions entity.SuggestionList
+		if json.Unmarshal([]byte(cached), &suggestions) == nil {
+			ctx.JSON(http.StatusOK, suggestions)
+			return
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, config.SuggestTimeout)
+	defer cancel()
+
+	suggestions, err := h.UseCase.SearchRepo.Suggest(timeoutCtx, req)
+	if err != nil && timeoutCtx.Err() != nil {
+		// out of time, whatever was found so far is better than an error, but it is not cached
+		h.Logger.Warn("handler - Suggest - timed out for %q", req.Query)
+		ctx.JSON(http.StatusOK, suggestions)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error getting suggestions") {
+		return
+	}
+
+	if js, err := json.Marshal(suggestions); err == nil {
+		if err := h.Redis.Set(ctx, key, string(js), int(config.SuggestCacheTTL.Seconds())); err != nil {
+			h.Logger.Error(fmt.Errorf("handler - Suggest - Redis.Set: %w", err))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// suggestCacheKey shares cached suggestions between callers about a kilometre apart
+func suggestCacheKey(hash func(string) string, req entity.SuggestRequest) string {
+	key := etc.NormalizeQuery(req.Query)
+	if req.Geo != nil {
+		key += fmt.Sprintf("|%.2f|%.2f", req.Geo.Latitude, req.Geo.Longitude)
+	}
+
+	return "suggest:" + hash(key)
+}
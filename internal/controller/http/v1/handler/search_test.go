@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/abdulazizax/yelp/config"
+)
+
+func TestClampSearchLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: config.SearchDefaultLimit},
+		{limit: -5, want: config.SearchDefaultLimit},
+		{limit: 1, want: 1},
+		{limit: 25, want: 25},
+		{limit: config.SearchMaxLimit, want: config.SearchMaxLimit},
+		{limit: 1000000, want: config.SearchMaxLimit},
+	}
+
+	for _, tt := range tests {
+		if got := clampSearchLimit(tt.limit); got != tt.want {
+			t.Errorf("clampSearchLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
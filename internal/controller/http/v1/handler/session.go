@@ -45,6 +45,10 @@ func (h *Handler) GetSession(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param user_id query string false "user_id"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, page is ignored when set"
+// @Param with_count query boolean false "include the total count, on by default without a cursor"
+// @Param filter query string false "filter[column][op]=value, op is eq (default), neq, gt, gte, lt, lte or contains. Columns: platform, is_active, expires_at, last_active_at, created_at"
+// @Param sort query string false "comma separated columns, a leading - sorts descending. Columns: expires_at, last_active_at, created_at"
 // @Success 200 {object} entity.SessionList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetSessions(ctx *gin.Context) {
@@ -75,11 +79,22 @@ func (h *Handler) GetSessions(ctx *gin.Context) {
 		Order:  "desc",
 	})
 
+	if err := parseListQuery(ctx.Request.URL.Query(), sessionListColumns, &req); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if !h.pageRequest(ctx, &req) {
+		return
+	}
+
 	sessions, err := h.UseCase.SessionRepo.GetList(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting session") {
 		return
 	}
 
+	h.signPage(&sessions.Page)
+
 	ctx.JSON(200, sessions)
 }
 
@@ -84,6 +84,10 @@ func (h *Handler) GetUser(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, page is ignored when set"
+// @Param with_count query boolean false "include the total count, on by default without a cursor"
+// @Param filter query string false "filter[column][op]=value, op is eq (default), neq, gt, gte, lt, lte or contains. Columns: full_name, username, email, user_type, user_role, gender, status, created_at"
+// @Param sort query string false "comma separated columns, a leading - sorts descending. Columns: full_name, username, created_at"
 // @Success 200 {object} entity.UserList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetUsers(ctx *gin.Context) {
@@ -120,11 +124,22 @@ func (h *Handler) GetUsers(ctx *gin.Context) {
 		Order:  "desc",
 	})
 
+	if err := parseListQuery(ctx.Request.URL.Query(), userListColumns, &req); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+		return
+	}
+
+	if !h.pageRequest(ctx, &req) {
+		return
+	}
+
 	users, err := h.UseCase.UserRepo.GetList(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting users") {
 		return
 	}
 
+	h.signPage(&users.Page)
+
 	ctx.JSON(200, users)
 }
 
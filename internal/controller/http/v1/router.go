@@ -16,7 +16,10 @@ import (
 	"github.com/abdulazizax/yelp/internal/controller/http/v1/handler"
 	"github.com/abdulazizax/yelp/internal/usecase"
 	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/mailer"
+	"github.com/abdulazizax/yelp/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
+	goredis "github.com/redis/go-redis/v9"
 )
 
 // NewRouter -.
@@ -29,12 +32,13 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) {
+func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache,
+	redisClient goredis.Cmdable, storage storage.Storage, mail mailer.Mailer) {
 	// Options
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 
-	handlerV1 := handler.NewHandler(l, config, useCase, redis)
+	handlerV1 := handler.NewHandler(l, config, useCase, redis, redisClient, storage, mail)
 
 	// Initialize Casbin enforcer
 	e := casbin.NewEnforcer("config/rbac.conf", "config/policy.csv")
@@ -50,6 +54,11 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 	// Prometheus metrics
 	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Uploaded files
+	if config.Storage.Driver == "local" {
+		engine.Static(config.Storage.BaseURL, config.Storage.LocalDir)
+	}
+
 	// Routes
 	v1 := engine.Group("/v1")
 
@@ -60,8 +69,15 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		user.GET("/:id", handlerV1.GetUser)
 		user.PUT("/", handlerV1.UpdateUser)
 		user.DELETE("/:id", handlerV1.DeleteUser)
+		user.POST("/:id/follow", handlerV1.FollowUser)
+		user.DELETE("/:id/follow", handlerV1.UnfollowUser)
+		user.GET("/:id/followers", handlerV1.GetFollowers)
+		user.GET("/:id/following", handlerV1.GetFollowing)
 	}
 
+	// Feed
+	v1.GET("/feed", handlerV1.GetFeed)
+
 	session := v1.Group("/session")
 	{
 		session.GET("/list", handlerV1.GetSessions)
@@ -75,7 +91,32 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		auth.POST("/logout", handlerV1.Logout)
 		auth.POST("/register", handlerV1.Register)
 		auth.POST("/verify-email", handlerV1.VerifyEmail)
+		auth.POST("/resend-otp", handlerV1.ResendOtp)
 		auth.POST("/login", handlerV1.Login)
+		auth.POST("/refresh", handlerV1.RefreshToken)
+		auth.POST("/forgot-password", handlerV1.ForgotPassword)
+		auth.POST("/reset-password", handlerV1.ResetPassword)
+	}
+
+	// Collection
+	collection := v1.Group("/collection")
+	{
+		collection.POST("/", handlerV1.CreateCollection)
+		collection.GET("/list", handlerV1.GetCollections)
+		collection.GET("/:id", handlerV1.GetCollection)
+		collection.PUT("/", handlerV1.UpdateCollection)
+		collection.DELETE("/:id", handlerV1.DeleteCollection)
+		collection.POST("/bookmark", handlerV1.BookmarkBusiness)
+		collection.POST("/:id/business", handlerV1.AddCollectionBusiness)
+		collection.DELETE("/:id/business/:business_id", handlerV1.RemoveCollectionBusiness)
+		collection.PUT("/:id/order", handlerV1.ReorderCollection)
+	}
+
+	// Search
+	search := v1.Group("/search")
+	{
+		search.GET("", handlerV1.Search)
+		search.GET("/suggest", handlerV1.Suggest)
 	}
 
 	// Business
@@ -86,6 +127,24 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		business.GET("/:id", handlerV1.GetBusiness)
 		business.PUT("/", handlerV1.UpdateBusiness)
 		business.DELETE("/:id", handlerV1.DeleteBusiness)
+		business.POST("/:id/attachment", handlerV1.UploadBusinessAttachment)
+		business.POST("/:id/claim", handlerV1.ClaimBusiness)
+		business.POST("/:id/check-in", handlerV1.CheckIn)
+	}
+
+	// Check-in
+	checkIn := v1.Group("/check-in")
+	{
+		checkIn.GET("/list", handlerV1.GetCheckIns)
+	}
+
+	// Business Claim
+	businessClaim := v1.Group("/business-claim")
+	{
+		businessClaim.GET("/list", handlerV1.GetBusinessClaims)
+		businessClaim.GET("/:id", handlerV1.GetBusinessClaim)
+		businessClaim.POST("/:id/approve", handlerV1.ApproveBusinessClaim)
+		businessClaim.POST("/:id/reject", handlerV1.RejectBusinessClaim)
 	}
 
 	// Business Category
@@ -93,9 +152,14 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 	{
 		businessCategory.POST("/", handlerV1.CreateBusinessCategory)
 		businessCategory.GET("/list", handlerV1.GetBusinessCategories)
+		businessCategory.GET("/tree", handlerV1.GetBusinessCategoryTree)
 		businessCategory.GET("/:id", handlerV1.GetBusinessCategory)
 		businessCategory.PUT("/", handlerV1.UpdateBusinessCategory)
 		businessCategory.DELETE("/:id", handlerV1.DeleteBusinessCategory)
+		businessCategory.POST("/:id/attribute", handlerV1.CreateBusinessAttribute)
+		businessCategory.GET("/:id/attribute/list", handlerV1.GetBusinessAttributes)
+		businessCategory.PUT("/attribute", handlerV1.UpdateBusinessAttribute)
+		businessCategory.DELETE("/attribute/:id", handlerV1.DeleteBusinessAttribute)
 	}
 
 	// Business Review
@@ -106,5 +170,22 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		review.GET("/:id", handlerV1.GetReview)
 		review.PUT("/", handlerV1.UpdateReview)
 		review.DELETE("/:id", handlerV1.DeleteReview)
+		review.POST("/:id/attachment", handlerV1.UploadReviewAttachment)
+		review.POST("/:id/reply", handlerV1.CreateReviewReply)
+		review.PUT("/:id/reply", handlerV1.UpdateReviewReply)
+		review.DELETE("/:id/reply", handlerV1.DeleteReviewReply)
+		review.POST("/:id/vote", handlerV1.VoteReview)
+		review.GET("/:id/history", handlerV1.GetReviewHistory)
+		review.POST("/:id/report", handlerV1.ReportReview)
+	}
+
+	// Moderation
+	moderation := v1.Group("/moderation")
+	{
+		moderation.GET("/reviews", handlerV1.GetModerationReviews)
+		moderation.GET("/reviews/:id/reports", handlerV1.GetModerationReviewReports)
+		moderation.POST("/reviews/:id/approve", handlerV1.ApproveReview)
+		moderation.POST("/reviews/:id/hide", handlerV1.HideReview)
+		moderation.POST("/reviews/:id/remove", handlerV1.RemoveReview)
 	}
 }
@@ -20,3 +20,21 @@ type VerifyEmail struct {
 	Otp      string `json:"otp"`
 	Platform string `json:"platform"`
 }
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Email    string `json:"email"`
+	Otp      string `json:"otp"`
+	Password string `json:"password"`
+}
+
+type ResendOtpRequest struct {
+	Email string `json:"email"`
+}
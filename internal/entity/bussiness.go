@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 // ContactInfo defines the structure for the contact_info field
 type ContactInfo struct {
 	Phone   string `json:"phone"`
@@ -7,37 +9,109 @@ type ContactInfo struct {
 	Website string `json:"website"`
 }
 
-// HoursOfOperation defines the structure for the hours_of_operation field
+// TimeRange is an opening interval in the business time zone, "HH:MM" each,
+// a range closing at or before its opening time runs past midnight (e.g. 22:00-02:00)
+type TimeRange struct {
+	Open  string `json:"open" example:"09:00"`
+	Close string `json:"close" example:"18:00"`
+}
+
+// HoursOfOperation defines the structure for the hours_of_operation field, a day without ranges is closed
 type HoursOfOperation struct {
-	Monday    string `json:"monday"`
-	Tuesday   string `json:"tuesday"`
-	Wednesday string `json:"wednesday"`
-	Thursday  string `json:"thursday"`
-	Friday    string `json:"friday"`
-	Saturday  string `json:"saturday"`
-	Sunday    string `json:"sunday"`
+	Monday    []TimeRange `json:"monday"`
+	Tuesday   []TimeRange `json:"tuesday"`
+	Wednesday []TimeRange `json:"wednesday"`
+	Thursday  []TimeRange `json:"thursday"`
+	Friday    []TimeRange `json:"friday"`
+	Saturday  []TimeRange `json:"saturday"`
+	Sunday    []TimeRange `json:"sunday"`
+}
+
+// Days returns the weekly ranges keyed by the JSON weekday name
+func (h HoursOfOperation) Days() map[string][]TimeRange {
+	return map[string][]TimeRange{
+		"monday":    h.Monday,
+		"tuesday":   h.Tuesday,
+		"wednesday": h.Wednesday,
+		"thursday":  h.Thursday,
+		"friday":    h.Friday,
+		"saturday":  h.Saturday,
+		"sunday":    h.Sunday,
+	}
 }
 
+// SpecialHours overrides the regular hours on a single date (holidays, events)
+type SpecialHours struct {
+	Date   string      `json:"date" example:"2026-12-31"`
+	Closed bool        `json:"closed"`
+	Hours  []TimeRange `json:"hours"`
+	Note   string      `json:"note"`
+}
+
+// RatingHistogram holds the number of reviews per star rating (1 to 5)
+type RatingHistogram map[int]int
+
 // Business represents the businesses table
 type Business struct {
-	ID               string               `json:"id"`
-	Name             string               `json:"name"`
-	Description      string               `json:"description"`
-	CategoryID       string               `json:"category_id"`
-	Address          string               `json:"address"`
-	Attachments      []BusinessAttachment `json:"attachments"`
-	Latitude         float64              `json:"latitude"`
-	Longitude        float64              `json:"longitude"`
-	ContactInfo      ContactInfo          `json:"contact_info"`
-	HoursOfOperation HoursOfOperation     `json:"hours_of_operation"`
-	OwnerID          string               `json:"owner_id"`
-	CreatedAt        string               `json:"created_at"`
-	UpdatedAt        string               `json:"updated_at"`
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	CategoryID       string                 `json:"category_id"` // primary category, always part of CategoryIDs
+	CategoryIDs      []string               `json:"category_ids"`
+	Address          string                 `json:"address"`
+	Attachments      []BusinessAttachment   `json:"attachments"`
+	Latitude         *float64               `json:"latitude,omitempty" example:"41.311081"` // left out with Longitude when unknown
+	Longitude        *float64               `json:"longitude,omitempty" example:"69.240562"`
+	ContactInfo      ContactInfo            `json:"contact_info"`
+	HoursOfOperation HoursOfOperation       `json:"hours_of_operation"`
+	SpecialHours     []SpecialHours         `json:"special_hours"`
+	TimeZone         string                 `json:"time_zone" example:"Asia/Tashkent"`
+	IsOpenNow        bool                   `json:"is_open_now"`
+	PriceLevel       *int                   `json:"price_level,omitempty" example:"2"`
+	Attributes       map[string]interface{} `json:"attributes"`
+	OwnerID          string                 `json:"owner_id"`
+	AvgRating        float64                `json:"avg_rating"`
+	ReviewCount      int                    `json:"review_count"`
+	RatingHistogram  RatingHistogram        `json:"rating_histogram"`
+	CheckInCount     int                    `json:"check_in_count"`
+	DistanceM        *float64               `json:"distance_m,omitempty"`
+	CreatedAt        string                 `json:"created_at"`
+	UpdatedAt        string                 `json:"updated_at"`
 }
 
 type BusinessList struct {
 	Items []Business `json:"businesses"`
-	Count int        `json:"count"`
+	Count *int       `json:"count,omitempty"` // left out when the request skips it
+	Page
+}
+
+// GeoFilter limits results to points within RadiusKm of the given coordinates
+type GeoFilter struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+// BoundingBox limits results to points inside the box, a box with MinLongitude above MaxLongitude crosses the antimeridian
+type BoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type BusinessListFilter struct {
+	GetListFilter
+	Geo         *GeoFilter   `json:"geo"`
+	BoundingBox *BoundingBox `json:"bounding_box"`
+	// CategoryID keeps businesses in the category or any of its descendants
+	CategoryID string `json:"category_id"`
+	// OpenAt keeps only businesses open at the given moment
+	OpenAt *time.Time `json:"open_at"`
+	// PriceLevels keeps businesses with one of the price levels
+	PriceLevels []int `json:"price_levels"`
+	// Attributes keeps businesses carrying every attribute value, amenities are matched as true
+	Attributes map[string]interface{} `json:"attributes"`
 }
 
 type BusinessSingleRequest struct {
@@ -48,10 +122,31 @@ type BusinessSingleRequest struct {
 
 // BusinessCategory defines the structure for the business_categories table
 type BusinessCategory struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	ParentID   string              `json:"parent_id"` // empty for a top level category
+	Children   []BusinessCategory  `json:"children,omitempty"`
+	Attributes []BusinessAttribute `json:"attributes,omitempty"`
+	CreatedAt  string              `json:"created_at"`
+	UpdatedAt  string              `json:"updated_at"`
+}
+
+// BusinessAttribute defines the structure for the business_category_attributes table,
+// a boolean attribute is an amenity, an enum attribute takes one of Options
+type BusinessAttribute struct {
+	ID         string   `json:"id"`
+	CategoryID string   `json:"category_id"`
+	Key        string   `json:"key" example:"wifi"`
+	Name       string   `json:"name" example:"Free Wi-Fi"`
+	Type       string   `json:"type" example:"boolean"`
+	Options    []string `json:"options"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type BusinessAttributeList struct {
+	Items []BusinessAttribute `json:"attributes"`
+	Count int                 `json:"count"`
 }
 
 type BusinessCategoryList struct {
@@ -66,12 +161,13 @@ type BusinessCategorySingleRequest struct {
 
 // BusinessAttachmentList defines the structure for the list of business attachments
 type BusinessAttachment struct {
-	Id          string `json:"id"`
-	BusinessId  string `json:"-"`
-	FilePath    string `json:"filepath"`
-	ContentType string `json:"content_type"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	Id          string            `json:"id"`
+	BusinessId  string            `json:"-"`
+	FilePath    string            `json:"filepath"`
+	ContentType string            `json:"content_type"`
+	Variants    map[string]string `json:"variants,omitempty"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
 }
 
 type BusinessAttachmentList struct {
@@ -83,3 +179,42 @@ type BusinessAttachmentMultipleInsertRequest struct {
 	BusinessId  string               `json:"business_id"`
 	Attachments []BusinessAttachment `json:"attachments"`
 }
+
+// BusinessClaim is the request of a user to become the owner of a business
+type BusinessClaim struct {
+	ID         string               `json:"id"`
+	BusinessID string               `json:"business_id"`
+	UserID     string               `json:"user_id"`
+	Status     string               `json:"status"` // pending, approved, rejected
+	Message    string               `json:"message"`
+	ReviewedBy string               `json:"reviewed_by,omitempty"`
+	Note       string               `json:"note,omitempty"`
+	Events     []BusinessClaimEvent `json:"events,omitempty"`
+	CreatedAt  string               `json:"created_at"`
+	UpdatedAt  string               `json:"updated_at"`
+}
+
+// BusinessClaimEvent records one step of a claim
+type BusinessClaimEvent struct {
+	ID        string `json:"id"`
+	ActorID   string `json:"actor_id"`
+	Action    string `json:"action"` // submitted, approved, rejected
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type BusinessClaimList struct {
+	Items []BusinessClaim `json:"items"`
+	Count int             `json:"count"`
+}
+
+type BusinessClaimRequest struct {
+	Message string `json:"message"`
+}
+
+// BusinessClaimDecision is the answer of an admin to a pending claim
+type BusinessClaimDecision struct {
+	ClaimID    string `json:"-"`
+	ReviewerID string `json:"-"`
+	Note       string `json:"note"`
+}
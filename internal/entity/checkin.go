@@ -0,0 +1,23 @@
+package entity
+
+// CheckIn records a visit of a user to a business, the coordinates are the ones the user sent
+type CheckIn struct {
+	ID           string   `json:"id"`
+	UserID       string   `json:"user_id"`
+	BusinessID   string   `json:"business_id"`
+	BusinessName string   `json:"business_name"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	DistanceM    *float64 `json:"distance_m,omitempty"` // from the business at the time of the check-in
+	CreatedAt    string   `json:"created_at"`
+}
+
+type CheckInRequest struct {
+	Latitude  *float64 `json:"latitude" example:"41.311081"`
+	Longitude *float64 `json:"longitude" example:"69.240562"`
+}
+
+type CheckInList struct {
+	Items []CheckIn `json:"check_ins"`
+	Count int       `json:"count"`
+}
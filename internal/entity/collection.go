@@ -0,0 +1,48 @@
+package entity
+
+// Collection is a user curated list of businesses, IsDefault marks the bookmarks collection
+type Collection struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Name        string           `json:"name" example:"Date night"`
+	Description string           `json:"description"`
+	IsPublic    bool             `json:"is_public"`
+	IsDefault   bool             `json:"is_default"`
+	ItemCount   int              `json:"item_count"`
+	Items       []CollectionItem `json:"items,omitempty"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+}
+
+type CollectionList struct {
+	Items []Collection `json:"collections"`
+	Count int          `json:"count"`
+}
+
+// CollectionItem is a business saved in a collection, ordered by Position
+type CollectionItem struct {
+	BusinessID   string `json:"business_id"`
+	BusinessName string `json:"business_name"`
+	Position     int    `json:"position"`
+	Note         string `json:"note"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// CollectionSingleRequest finds a collection by ID or the default collection of UserID
+type CollectionSingleRequest struct {
+	ID      string `json:"id"`
+	UserID  string `json:"user_id"`
+	Default bool   `json:"default"`
+}
+
+type CollectionItemRequest struct {
+	CollectionID string `json:"-"`
+	BusinessID   string `json:"business_id"`
+	Note         string `json:"note"`
+}
+
+// CollectionOrderRequest lists every business of the collection in the new order
+type CollectionOrderRequest struct {
+	CollectionID string   `json:"-"`
+	BusinessIDs  []string `json:"business_ids"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+type FollowRequest struct {
+	FollowerID string `json:"follower_id"`
+	FolloweeID string `json:"followee_id"`
+}
+
+type FollowListRequest struct {
+	UserID string `json:"user_id"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+}
+
+// FollowUser is a follower or a followed user
+type FollowUser struct {
+	ID             string `json:"id"`
+	FullName       string `json:"full_name"`
+	Username       string `json:"username"`
+	ProfilePicture string `json:"profile_picture"`
+	FollowedAt     string `json:"followed_at"`
+}
+
+type FollowList struct {
+	Items []FollowUser `json:"users"`
+	Count int          `json:"count"`
+}
+
+// FeedRequest reads the items older than Cursor, which holds created_at and the ID of the last item of a page
+type FeedRequest struct {
+	UserID string      `json:"user_id"`
+	Cursor *PageCursor `json:"-"`
+	Limit  int         `json:"limit"`
+}
+
+// FeedItem is a review or a review photo of a followed user, Photo is set for photos only
+type FeedItem struct {
+	Type         string            `json:"type" example:"review"`
+	ID           string            `json:"id"`
+	ReviewID     string            `json:"review_id"`
+	UserID       string            `json:"user_id"`
+	UserFullName string            `json:"user_full_name"`
+	BusinessID   string            `json:"business_id"`
+	BusinessName string            `json:"business_name"`
+	Rating       int               `json:"rating"`
+	Comment      string            `json:"comment,omitempty"`
+	Photo        *ReviewAttachment `json:"photo,omitempty"`
+	CreatedAt    string            `json:"created_at"`
+}
+
+type FeedPage struct {
+	Items []FeedItem `json:"items"`
+	Page
+}
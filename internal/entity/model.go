@@ -1,5 +1,7 @@
 package entity
 
+import "strings"
+
 type Id struct {
 	ID string `json:"id"`
 }
@@ -11,7 +13,7 @@ type OrderBy struct {
 
 type Filter struct {
 	Column string `json:"column"`
-	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search
+	Type   string `json:"type"` // eq, neq, gt, gte, lt, lte, search, contains, fts, similar
 	Value  string `json:"value"`
 }
 
@@ -20,6 +22,36 @@ type GetListFilter struct {
 	Limit   int       `json:"limit"`
 	Filters []Filter  `json:"filters"`
 	OrderBy []OrderBy `json:"order_by"`
+	// Cursor pages by the OrderBy values of a row instead of Page, lists that support it say so
+	Cursor *PageCursor `json:"-"`
+	// SkipCount leaves out the total count, which costs a second query
+	SkipCount bool `json:"-"`
+}
+
+// OrderKey names the order of the list, a cursor only applies to the order it was made for
+func (f GetListFilter) OrderKey() string {
+	keys := make([]string, 0, len(f.OrderBy))
+	for _, e := range f.OrderBy {
+		keys = append(keys, e.Column+" "+e.Order)
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// PageCursor is a keyset position: the OrderBy values of a row, followed by its ID.
+// Backward asks for the rows before it instead of the rows after it.
+type PageCursor struct {
+	Order    string   `json:"o"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Page holds the cursors of the pages next to a list page, the handler signs Next and Prev into NextCursor and PrevCursor
+type Page struct {
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+	Next       *PageCursor `json:"-"`
+	Prev       *PageCursor `json:"-"`
 }
 
 type UpdateFieldItem struct {
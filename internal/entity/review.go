@@ -1,36 +1,61 @@
 package entity
 
+import "time"
+
 // Review represents the reviewes table
 type Review struct {
-	ID          string             `json:"id"`
-	BusinessID  string             `json:"business_id"`
-	UserID      string             `json:"user_id"`
-	Rating      uint8              `json:"rating"`
-	Comment     string             `json:"comment"`
-	Attachments []ReviewAttachment `json:"attachments"`
-	CreatedAt   string             `json:"created_at"`
-	UpdatedAt   string             `json:"updated_at"`
+	ID               string             `json:"id"`
+	BusinessID       string             `json:"business_id"`
+	UserID           string             `json:"user_id"`
+	Rating           uint8              `json:"rating"`
+	Comment          string             `json:"comment"`
+	Attachments      []ReviewAttachment `json:"attachments"`
+	Reply            *ReviewReply       `json:"reply,omitempty"`
+	Votes            ReviewVotes        `json:"votes"`
+	MyVote           string             `json:"my_vote,omitempty"`
+	Status           string             `json:"status"` // pending, published, hidden, removed
+	ReportCount      int                `json:"report_count"`
+	ModerationReason string             `json:"moderation_reason,omitempty"` // screening reason code of a held review
+	CreatedAt        string             `json:"created_at"`
+	UpdatedAt        string             `json:"updated_at"`
 }
 
 type ReviewList struct {
 	Items []Review `json:"reviewes"`
-	Count int      `json:"count"`
+	Count *int     `json:"count,omitempty"` // left out when the request skips it
+	Page
 }
 
+// ReviewListFilter lists published reviews and every review of ViewerID, WithHidden lists reviews of any status
+type ReviewListFilter struct {
+	GetListFilter
+	ViewerID   string `json:"-"`
+	WithHidden bool   `json:"-"`
+}
+
+// RecentReviewsRequest lists the latest reviews of a user written within Window
+type RecentReviewsRequest struct {
+	UserID string
+	Window time.Duration
+	Limit  int
+}
+
+// ReviewSingleRequest finds a review by its id or by its author and business
 type ReviewSingleRequest struct {
 	ID         string `json:"id"`
-	OwnerID    string `json:"owner_id"`
-	CategoryID string `json:"category_id"`
+	BusinessID string `json:"business_id"`
+	UserID     string `json:"user_id"`
 }
 
 // ReviewAttachmentList defines the structure for the list of review attachments
 type ReviewAttachment struct {
-	Id          string `json:"id"`
-	ReviewId    string `json:"-"`
-	FilePath    string `json:"filepath"`
-	ContentType string `json:"content_type"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	Id          string            `json:"id"`
+	ReviewId    string            `json:"-"`
+	FilePath    string            `json:"filepath"`
+	ContentType string            `json:"content_type"`
+	Variants    map[string]string `json:"variants,omitempty"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
 }
 
 type ReviewAttachmentList struct {
@@ -42,3 +67,88 @@ type ReviewAttachmentMultipleInsertRequest struct {
 	ReviewId    string             `json:"review_id"`
 	Attachments []ReviewAttachment `json:"attachments"`
 }
+
+// ReviewReply is the answer of the business owner to a review
+type ReviewReply struct {
+	ID        string `json:"id"`
+	ReviewID  string `json:"review_id"`
+	UserID    string `json:"user_id"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ReviewReplySingleRequest struct {
+	ID       string `json:"id"`
+	ReviewID string `json:"review_id"`
+}
+
+type ReviewReplyRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ReviewVotes counts the votes a review received by type
+type ReviewVotes struct {
+	Useful int `json:"useful"`
+	Funny  int `json:"funny"`
+	Cool   int `json:"cool"`
+}
+
+// ReviewVote is the vote of a user on a review, a user has one vote per review
+type ReviewVote struct {
+	ReviewID  string `json:"review_id"`
+	UserID    string `json:"user_id"`
+	Type      string `json:"type"` // useful, funny, cool
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ReviewVoteSingleRequest struct {
+	ReviewID string `json:"review_id"`
+	UserID   string `json:"user_id"`
+}
+
+// ReviewVoteRequest votes on a review, voting the same type again takes the vote back
+type ReviewVoteRequest struct {
+	Type string `json:"type"`
+}
+
+type ReviewUserVotesRequest struct {
+	UserID    string   `json:"user_id"`
+	ReviewIDs []string `json:"review_ids"`
+}
+
+// ReviewRevision is a previous version of a review, kept whenever its rating or comment changes
+type ReviewRevision struct {
+	ID         string `json:"id"`
+	ReviewID   string `json:"review_id"`
+	Rating     uint8  `json:"rating"`
+	Comment    string `json:"comment"`
+	CreatedAt  string `json:"created_at"`
+	ReplacedAt string `json:"replaced_at"`
+}
+
+type ReviewRevisionList struct {
+	Items []ReviewRevision `json:"items"`
+	Count int              `json:"count"`
+}
+
+// ReviewReport flags a review for moderation, a user reports a review once
+type ReviewReport struct {
+	ID        string `json:"id"`
+	ReviewID  string `json:"review_id"`
+	UserID    string `json:"user_id"`
+	Reason    string `json:"reason"`
+	Resolved  bool   `json:"resolved"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ReviewReportList struct {
+	Items []ReviewReport `json:"items"`
+	Count int            `json:"count"`
+}
+
+type ReviewReportRequest struct {
+	Reason string `json:"reason"`
+}
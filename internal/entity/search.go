@@ -0,0 +1,50 @@
+package entity
+
+// SearchRequest is a ranked full-text query over businesses and reviews
+type SearchRequest struct {
+	Query      string `json:"query"`
+	Businesses bool   `json:"businesses"`
+	Reviews    bool   `json:"reviews"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+}
+
+// SearchResult is a single match, Snippet is escaped HTML marking the matched words with <mark></mark>
+type SearchResult struct {
+	Type       string  `json:"type" example:"business"`
+	ID         string  `json:"id"`
+	BusinessID string  `json:"business_id"`
+	Title      string  `json:"title"`
+	Snippet    string  `json:"snippet"`
+	Rank       float64 `json:"rank"`
+}
+
+type SearchResultList struct {
+	Items []SearchResult `json:"results"`
+	Count int            `json:"count"`
+}
+
+// SearchQueryRecord is a search of a user that found something
+type SearchQueryRecord struct {
+	Query  string `json:"query"`
+	UserID string `json:"user_id"`
+}
+
+// SuggestRequest asks for as-you-type suggestions, nearby businesses rank higher when Geo is set
+type SuggestRequest struct {
+	Query string     `json:"query"`
+	Geo   *GeoFilter `json:"geo"`
+	Limit int        `json:"limit"`
+}
+
+// Suggestion is a business, a category or a popular search completing the typed text
+type Suggestion struct {
+	Type      string   `json:"type" example:"business"`
+	ID        string   `json:"id,omitempty"`
+	Text      string   `json:"text"`
+	DistanceM *float64 `json:"distance_m,omitempty"`
+}
+
+type SuggestionList struct {
+	Items []Suggestion `json:"suggestions"`
+}
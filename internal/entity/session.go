@@ -1,19 +1,31 @@
 package entity
 
 type Session struct {
-	ID           string `json:"id"`
-	UserID       string `json:"user_id"`
-	IPAddress    string `json:"ip_address"`
-	UserAgent    string `json:"user_agent"`
-	IsActive     bool   `json:"is_active"`
-	ExpiresAt    string `json:"expires_at"`
-	LastActiveAt string `json:"last_active_at"`
-	Platform     string `json:"platform"`
+	ID               string `json:"id"`
+	UserID           string `json:"user_id"`
+	IPAddress        string `json:"ip_address"`
+	UserAgent        string `json:"user_agent"`
+	IsActive         bool   `json:"is_active"`
+	ExpiresAt        string `json:"expires_at"`
+	LastActiveAt     string `json:"last_active_at"`
+	Platform         string `json:"platform"`
+	RefreshTokenHash string `json:"-"`
+	// RefreshToken is only returned when a session is created or its token is rotated
+	RefreshToken string `json:"refresh_token,omitempty"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
 }
 
 type SessionList struct {
 	Items []Session `json:"sessions"`
-	Count int       `json:"count"`
+	Count *int      `json:"count,omitempty"` // left out when the request skips it
+	Page
+}
+
+// RefreshTokenRotation replaces the refresh token of a session, OldHash must still be its current token hash
+type RefreshTokenRotation struct {
+	SessionID string
+	OldHash   string
+	NewHash   string
+	ExpiresAt string
 }
@@ -25,5 +25,6 @@ type UserSingleRequest struct {
 
 type UserList struct {
 	Items []User `json:"users"`
-	Count int    `json:"count"`
+	Count *int   `json:"count,omitempty"` // left out when the request skips it
+	Page
 }
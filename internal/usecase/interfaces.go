@@ -28,18 +28,29 @@ type (
 		Update(ctx context.Context, req entity.Session) (entity.Session, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+		RotateRefreshToken(ctx context.Context, req entity.RefreshTokenRotation) (entity.RowsEffected, error)
+		IsRotatedRefreshToken(ctx context.Context, sessionID, tokenHash string) (bool, error)
 	}
 
 	// BusinessRepo
 	BusinessRepoI interface {
 		Create(ctx context.Context, req entity.Business) (entity.Business, error)
 		GetSingle(ctx context.Context, req entity.BusinessSingleRequest) (entity.Business, error)
-		GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessList, error)
+		GetList(ctx context.Context, req entity.BusinessListFilter) (entity.BusinessList, error)
 		Update(ctx context.Context, req entity.Business) (entity.Business, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
 	}
 
+	// BusinessClaimRepo
+	BusinessClaimRepoI interface {
+		Create(ctx context.Context, req entity.BusinessClaim) (entity.BusinessClaim, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.BusinessClaim, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessClaimList, error)
+		Approve(ctx context.Context, req entity.BusinessClaimDecision) (entity.BusinessClaim, error)
+		Reject(ctx context.Context, req entity.BusinessClaimDecision) (entity.BusinessClaim, error)
+	}
+
 	// BusinessCategoryRepo
 	BusinessCategoryRepoI interface {
 		Create(ctx context.Context, req entity.BusinessCategory) (entity.BusinessCategory, error)
@@ -47,6 +58,18 @@ type (
 		GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessCategoryList, error)
 		Update(ctx context.Context, req entity.BusinessCategory) (entity.BusinessCategory, error)
 		Delete(ctx context.Context, req entity.Id) error
+		GetTree(ctx context.Context) (entity.BusinessCategoryList, error)
+		GetDescendantIDs(ctx context.Context, req entity.Id) ([]string, error)
+		GetAncestorIDs(ctx context.Context, req entity.Id) ([]string, error)
+	}
+
+	// BusinessAttributeRepo
+	BusinessAttributeRepoI interface {
+		Create(ctx context.Context, req entity.BusinessAttribute) (entity.BusinessAttribute, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.BusinessAttribute, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessAttributeList, error)
+		Update(ctx context.Context, req entity.BusinessAttribute) (entity.BusinessAttribute, error)
+		Delete(ctx context.Context, req entity.Id) error
 	}
 
 	// BusinessAttachmentRepo
@@ -61,10 +84,42 @@ type (
 	// ReviewRepo
 	ReviewRepoI interface {
 		Create(ctx context.Context, req entity.Review) (entity.Review, error)
-		GetSingle(ctx context.Context, req entity.Id) (entity.Review, error)
-		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewList, error)
+		GetSingle(ctx context.Context, req entity.ReviewSingleRequest) (entity.Review, error)
+		GetList(ctx context.Context, req entity.ReviewListFilter) (entity.ReviewList, error)
+		GetRecent(ctx context.Context, req entity.RecentReviewsRequest) ([]entity.Review, error)
 		Update(ctx context.Context, req entity.Review) (entity.Review, error)
 		Delete(ctx context.Context, req entity.Id) error
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// ReviewReportRepo
+	ReviewReportRepoI interface {
+		Create(ctx context.Context, req entity.ReviewReport) (entity.ReviewReport, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewReportList, error)
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// ReviewReplyRepo
+	ReviewReplyRepoI interface {
+		Create(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error)
+		GetSingle(ctx context.Context, req entity.ReviewReplySingleRequest) (entity.ReviewReply, error)
+		Update(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// ReviewVoteRepo
+	ReviewVoteRepoI interface {
+		Create(ctx context.Context, req entity.ReviewVote) (entity.ReviewVote, error)
+		GetSingle(ctx context.Context, req entity.ReviewVoteSingleRequest) (entity.ReviewVote, error)
+		Update(ctx context.Context, req entity.ReviewVote) (entity.ReviewVote, error)
+		Delete(ctx context.Context, req entity.ReviewVoteSingleRequest) error
+		// GetUserVotes returns the vote type of the user keyed by review id
+		GetUserVotes(ctx context.Context, req entity.ReviewUserVotesRequest) (map[string]string, error)
+	}
+
+	// ReviewRevisionRepo
+	ReviewRevisionRepoI interface {
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewRevisionList, error)
 	}
 
 	// ReviewAttachmentRepo
@@ -75,4 +130,41 @@ type (
 		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewAttachmentList, error)
 		Delete(ctx context.Context, req entity.Id) error
 	}
+
+	// SearchRepo
+	SearchRepoI interface {
+		Search(ctx context.Context, req entity.SearchRequest) (entity.SearchResultList, error)
+		Suggest(ctx context.Context, req entity.SuggestRequest) (entity.SuggestionList, error)
+		RecordQuery(ctx context.Context, req entity.SearchQueryRecord) error
+	}
+
+	// CollectionRepo
+	CollectionRepoI interface {
+		Create(ctx context.Context, req entity.Collection) (entity.Collection, error)
+		GetDefault(ctx context.Context, req entity.Id) (entity.Collection, error)
+		GetSingle(ctx context.Context, req entity.CollectionSingleRequest) (entity.Collection, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionList, error)
+		Update(ctx context.Context, req entity.Collection) (entity.Collection, error)
+		Delete(ctx context.Context, req entity.Id) error
+		GetItems(ctx context.Context, req entity.Id) ([]entity.CollectionItem, error)
+		AddItem(ctx context.Context, req entity.CollectionItemRequest) error
+		RemoveItem(ctx context.Context, req entity.CollectionItemRequest) error
+		Reorder(ctx context.Context, req entity.CollectionOrderRequest) error
+	}
+
+	// FollowRepo
+	FollowRepoI interface {
+		Create(ctx context.Context, req entity.FollowRequest) error
+		Delete(ctx context.Context, req entity.FollowRequest) error
+		GetFollowers(ctx context.Context, req entity.FollowListRequest) (entity.FollowList, error)
+		GetFollowing(ctx context.Context, req entity.FollowListRequest) (entity.FollowList, error)
+		GetFeed(ctx context.Context, req entity.FeedRequest) (entity.FeedPage, error)
+	}
+
+	// CheckInRepo
+	CheckInRepoI interface {
+		Create(ctx context.Context, req entity.CheckIn) (entity.CheckIn, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.CheckIn, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CheckInList, error)
+	}
 )
@@ -16,6 +16,16 @@ type UseCase struct {
 	BusinessAttachmentRepo BusinessAttachmentRepoI
 	ReviewRepo             ReviewRepoI
 	ReviewAttachmentRepo   ReviewAttachmentRepoI
+	ReviewReplyRepo        ReviewReplyRepoI
+	ReviewVoteRepo         ReviewVoteRepoI
+	ReviewRevisionRepo     ReviewRevisionRepoI
+	ReviewReportRepo       ReviewReportRepoI
+	BusinessClaimRepo      BusinessClaimRepoI
+	BusinessAttributeRepo  BusinessAttributeRepoI
+	SearchRepo             SearchRepoI
+	CollectionRepo         CollectionRepoI
+	FollowRepo             FollowRepoI
+	CheckInRepo            CheckInRepoI
 }
 
 // New -.
@@ -28,5 +38,15 @@ func New(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *U
 		BusinessAttachmentRepo: repo.NewBusinessAttachmentRepo(pg, config, logger),
 		ReviewRepo:             repo.NewReviewRepo(pg, config, logger),
 		ReviewAttachmentRepo:   repo.NewReviewAttachmentRepo(pg, config, logger),
+		ReviewReplyRepo:        repo.NewReviewReplyRepo(pg, config, logger),
+		ReviewVoteRepo:         repo.NewReviewVoteRepo(pg, config, logger),
+		ReviewRevisionRepo:     repo.NewReviewRevisionRepo(pg, config, logger),
+		ReviewReportRepo:       repo.NewReviewReportRepo(pg, config, logger),
+		BusinessClaimRepo:      repo.NewBusinessClaimRepo(pg, config, logger),
+		BusinessAttributeRepo:  repo.NewBusinessAttributeRepo(pg, config, logger),
+		SearchRepo:             repo.NewSearchRepo(pg, config, logger),
+		CollectionRepo:         repo.NewCollectionRepo(pg, config, logger),
+		FollowRepo:             repo.NewFollowRepo(pg, config, logger),
+		CheckInRepo:            repo.NewCheckInRepo(pg, config, logger),
 	}
 }
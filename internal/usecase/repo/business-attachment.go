@@ -32,8 +32,8 @@ func (r *BusinessAttachmentRepo) Create(ctx context.Context, req entity.Business
 	req.Id = uuid.NewString()
 
 	qeury, args, err := r.pg.Builder.Insert("business_attachments").
-		Columns(`id, business_id, filepath, content_type`).
-		Values(req.Id, req.BusinessId, req.FilePath, req.ContentType).ToSql()
+		Columns(`id, business_id, filepath, content_type, variants`).
+		Values(req.Id, req.BusinessId, req.FilePath, req.ContentType, variantsToJSON(req.Variants)).ToSql()
 	if err != nil {
 		return entity.BusinessAttachment{}, err
 	}
@@ -46,6 +46,8 @@ func (r *BusinessAttachmentRepo) Create(ctx context.Context, req entity.Business
 	return req, nil
 }
 
+// MultipleUpsert keeps the listed attachments of a business and deletes the others. Attachments without an id are
+// inserted as they are, without variants, so handlers only list attachments made by an upload.
 func (r *BusinessAttachmentRepo) MultipleUpsert(ctx context.Context, req entity.BusinessAttachmentMultipleInsertRequest) ([]entity.BusinessAttachment, error) {
 	hasNewAttachment := false
 
@@ -157,10 +159,11 @@ func (r *BusinessAttachmentRepo) GetSingle(ctx context.Context, req entity.Id) (
 	response := entity.BusinessAttachment{}
 	var (
 		createdAt, updatedAt time.Time
+		variants             []byte
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, business_id, filepath, content_type, created_at, updated_at`).
+		Select(`id, business_id, filepath, content_type, variants, created_at, updated_at`).
 		From("business_attachments")
 
 	switch {
@@ -176,7 +179,12 @@ func (r *BusinessAttachmentRepo) GetSingle(ctx context.Context, req entity.Id) (
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
-		Scan(&response.Id, &response.BusinessId, &response.FilePath, &response.ContentType, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.BusinessId, &response.FilePath, &response.ContentType, &variants, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.BusinessAttachment{}, err
+	}
+
+	response.Variants, err = variantsFromJSON(variants)
 	if err != nil {
 		return entity.BusinessAttachment{}, err
 	}
@@ -191,10 +199,11 @@ func (r *BusinessAttachmentRepo) GetList(ctx context.Context, req entity.GetList
 	var (
 		response             = entity.BusinessAttachmentList{}
 		createdAt, updatedAt time.Time
+		variants             []byte
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, business_id, filepath, content_type, created_at, updated_at`).
+		Select(`id, business_id, filepath, content_type, variants, created_at, updated_at`).
 		From("business_attachments")
 
 	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
@@ -212,7 +221,12 @@ func (r *BusinessAttachmentRepo) GetList(ctx context.Context, req entity.GetList
 
 	for rows.Next() {
 		var item entity.BusinessAttachment
-		err = rows.Scan(&item.Id, &item.BusinessId, &item.FilePath, &item.ContentType, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.BusinessId, &item.FilePath, &item.ContentType, &variants, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.Variants, err = variantsFromJSON(variants)
 		if err != nil {
 			return response, err
 		}
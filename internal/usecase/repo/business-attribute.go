@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+type BusinessAttributeRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewBusinessAttributeRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BusinessAttributeRepo {
+	return &BusinessAttributeRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *BusinessAttributeRepo) Create(ctx context.Context, req entity.BusinessAttribute) (entity.BusinessAttribute, error) {
+	req.ID = uuid.NewString()
+
+	qeury, args, err := r.pg.Builder.Insert("business_category_attributes").
+		Columns(`id, category_id, key, name, type, options`).
+		Values(req.ID, req.CategoryID, req.Key, req.Name, req.Type, req.Options).ToSql()
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	return req, nil
+}
+
+func (r *BusinessAttributeRepo) GetSingle(ctx context.Context, req entity.Id) (entity.BusinessAttribute, error) {
+	var (
+		response             entity.BusinessAttribute
+		createdAt, updatedAt time.Time
+	)
+
+	qeury, args, err := r.pg.Builder.
+		Select(`id, category_id, key, name, type, options, created_at, updated_at`).
+		From("business_category_attributes").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
+		Scan(&response.ID, &response.CategoryID, &response.Key, &response.Name, &response.Type, &response.Options, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *BusinessAttributeRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessAttributeList, error) {
+	var (
+		response             = entity.BusinessAttributeList{}
+		createdAt, updatedAt time.Time
+	)
+
+	qeuryBuilder := r.pg.Builder.
+		Select(`id, category_id, key, name, type, options, created_at, updated_at`).
+		From("business_category_attributes")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.BusinessAttribute
+		err = rows.Scan(&item.ID, &item.CategoryID, &item.Key, &item.Name, &item.Type, &item.Options, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("business_category_attributes").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *BusinessAttributeRepo) Update(ctx context.Context, req entity.BusinessAttribute) (entity.BusinessAttribute, error) {
+	mp := map[string]interface{}{
+		"key":        req.Key,
+		"name":       req.Name,
+		"type":       req.Type,
+		"options":    req.Options,
+		"updated_at": "now()",
+	}
+
+	qeury, args, err := r.pg.Builder.Update("business_category_attributes").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessAttribute{}, err
+	}
+
+	return req, nil
+}
+
+func (r *BusinessAttributeRepo) Delete(ctx context.Context, req entity.Id) error {
+	qeury, args, err := r.pg.Builder.Delete("business_category_attributes").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
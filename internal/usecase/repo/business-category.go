@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -31,8 +32,8 @@ func (r *BusinessCategoryRepo) Create(ctx context.Context, req entity.BusinessCa
 	req.ID = uuid.NewString()
 
 	qeury, args, err := r.pg.Builder.Insert("business_categories").
-		Columns(`id, name`).
-		Values(req.ID, req.Name).ToSql()
+		Columns(`id, name, parent_id`).
+		Values(req.ID, req.Name, nullString(req.ParentID)).ToSql()
 	if err != nil {
 		return entity.BusinessCategory{}, err
 	}
@@ -49,16 +50,17 @@ func (r *BusinessCategoryRepo) GetSingle(ctx context.Context, req entity.Busines
 	response := entity.BusinessCategory{}
 	var (
 		createdAt, updatedAt time.Time
+		parentID             sql.NullString
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, name, created_at, updated_at`).
+		Select(`id, name, parent_id, created_at, updated_at`).
 		From("business_categories")
 
 	switch {
 	case req.ID != "":
 		qeuryBuilder = qeuryBuilder.Where("id = ?", req.ID)
-	case req.ID != "":
+	case req.Name != "":
 		qeuryBuilder = qeuryBuilder.Where("name = ?", req.Name)
 	default:
 		return entity.BusinessCategory{}, fmt.Errorf("GetSingle - invalid request")
@@ -70,11 +72,12 @@ func (r *BusinessCategoryRepo) GetSingle(ctx context.Context, req entity.Busines
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
-		Scan(&response.ID, &response.Name, &createdAt, &updatedAt)
+		Scan(&response.ID, &response.Name, &parentID, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.BusinessCategory{}, err
 	}
 
+	response.ParentID = parentID.String
 	response.CreatedAt = createdAt.Format(time.RFC3339)
 	response.UpdatedAt = updatedAt.Format(time.RFC3339)
 
@@ -85,10 +88,11 @@ func (r *BusinessCategoryRepo) GetList(ctx context.Context, req entity.GetListFi
 	var (
 		response             = entity.BusinessCategoryList{}
 		createdAt, updatedAt time.Time
+		parentID             sql.NullString
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, name, created_at, updated_at`).
+		Select(`id, name, parent_id, created_at, updated_at`).
 		From("business_categories")
 
 	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
@@ -106,11 +110,12 @@ func (r *BusinessCategoryRepo) GetList(ctx context.Context, req entity.GetListFi
 
 	for rows.Next() {
 		var item entity.BusinessCategory
-		err = rows.Scan(&item.ID, &item.Name, &createdAt, &updatedAt)
+		err = rows.Scan(&item.ID, &item.Name, &parentID, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
 
+		item.ParentID = parentID.String
 		item.CreatedAt = createdAt.Format(time.RFC3339)
 		item.UpdatedAt = updatedAt.Format(time.RFC3339)
 
@@ -132,8 +137,9 @@ func (r *BusinessCategoryRepo) GetList(ctx context.Context, req entity.GetListFi
 
 func (r *BusinessCategoryRepo) Update(ctx context.Context, req entity.BusinessCategory) (entity.BusinessCategory, error) {
 	mp := map[string]interface{}{
-		"name":               req.Name,
-		"updated_at":         "now()",
+		"name":       req.Name,
+		"parent_id":  nullString(req.ParentID),
+		"updated_at": "now()",
 	}
 
 	qeury, args, err := r.pg.Builder.Update("business_categories").SetMap(mp).Where("id = ?", req.ID).ToSql()
@@ -162,3 +168,132 @@ func (r *BusinessCategoryRepo) Delete(ctx context.Context, req entity.Id) error
 
 	return nil
 }
+
+// GetTree returns the top level categories with their subcategories nested as children, Count holds every category
+func (r *BusinessCategoryRepo) GetTree(ctx context.Context) (entity.BusinessCategoryList, error) {
+	var (
+		response             = entity.BusinessCategoryList{}
+		createdAt, updatedAt time.Time
+		parentID             sql.NullString
+		children             = map[string][]entity.BusinessCategory{}
+	)
+
+	qeury, args, err := r.pg.Builder.
+		Select(`id, name, parent_id, created_at, updated_at`).
+		From("business_categories").
+		OrderBy("name").ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.BusinessCategory
+		err = rows.Scan(&item.ID, &item.Name, &parentID, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.ParentID = parentID.String
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		children[item.ParentID] = append(children[item.ParentID], item)
+		response.Count++
+	}
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	response.Items = nestCategories(children, "")
+
+	return response, nil
+}
+
+// GetDescendantIDs returns the category together with every category below it
+func (r *BusinessCategoryRepo) GetDescendantIDs(ctx context.Context, req entity.Id) ([]string, error) {
+	var ids []string
+
+	qeury, args, err := r.pg.Builder.
+		Select("id::text").
+		Prefix(categoryDescendantsCTE, req.ID).
+		From("category_tree").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetAncestorIDs returns the category followed by the categories above it, the nearest first
+func (r *BusinessCategoryRepo) GetAncestorIDs(ctx context.Context, req entity.Id) ([]string, error) {
+	var ids []string
+
+	qeury, args, err := r.pg.Builder.
+		Select("id::text").
+		Prefix(categoryAncestorsCTE, req.ID).
+		From("category_tree").
+		OrderBy("depth").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// categoryDescendantsCTE walks down from a category, category_tree holds it and every category below it
+const categoryDescendantsCTE = `WITH RECURSIVE category_tree AS (
+		SELECT id FROM business_categories WHERE id = ?
+		UNION
+		SELECT c.id FROM business_categories c JOIN category_tree t ON c.parent_id = t.id
+	)`
+
+// categoryAncestorsCTE walks up from a category, category_tree holds it and every category above it with its distance.
+// Updates never let a category become its own ancestor, so the walk ends at a root.
+const categoryAncestorsCTE = `WITH RECURSIVE category_tree AS (
+		SELECT id, parent_id, 0 AS depth FROM business_categories WHERE id = ?
+		UNION ALL
+		SELECT c.id, c.parent_id, t.depth + 1 FROM business_categories c JOIN category_tree t ON c.id = t.parent_id
+	)`
+
+func nestCategories(children map[string][]entity.BusinessCategory, parentID string) []entity.BusinessCategory {
+	items := children[parentID]
+	for i := range items {
+		items[i].Children = nestCategories(children, items[i].ID)
+	}
+
+	return items
+}
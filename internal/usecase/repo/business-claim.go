@@ -0,0 +1,317 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	claimEventSubmitted = "submitted"
+	claimEventApproved  = "approved"
+	claimEventRejected  = "rejected"
+)
+
+type BusinessClaimRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewBusinessClaimRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BusinessClaimRepo {
+	return &BusinessClaimRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *BusinessClaimRepo) Create(ctx context.Context, req entity.BusinessClaim) (entity.BusinessClaim, error) {
+	req.ID = uuid.NewString()
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	qeury, args, err := r.pg.Builder.Insert("business_claims").
+		Columns(`id, business_id, user_id, status, message`).
+		Values(req.ID, req.BusinessID, req.UserID, config.ClaimStatusPending, nullString(req.Message)).ToSql()
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = r.addEvent(ctx, tx, req.ID, req.UserID, claimEventSubmitted, "")
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *BusinessClaimRepo) GetSingle(ctx context.Context, req entity.Id) (entity.BusinessClaim, error) {
+	qeury, args, err := r.pg.Builder.
+		Select(businessClaimColumns).
+		From("business_claims").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	response, err := scanBusinessClaim(r.pg.Pool.QueryRow(ctx, qeury, args...))
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	qeury, args, err = r.pg.Builder.
+		Select(`id, actor_id, action, note, created_at`).
+		From("business_claim_events").
+		Where("claim_id = ?", req.ID).
+		OrderBy("created_at").ToSql()
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event         entity.BusinessClaimEvent
+			actorID, note sql.NullString
+			createdAt     time.Time
+		)
+
+		err = rows.Scan(&event.ID, &actorID, &event.Action, &note, &createdAt)
+		if err != nil {
+			return entity.BusinessClaim{}, err
+		}
+
+		event.ActorID = actorID.String
+		event.Note = note.String
+		event.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Events = append(response.Events, event)
+	}
+
+	return response, rows.Err()
+}
+
+func (r *BusinessClaimRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessClaimList, error) {
+	response := entity.BusinessClaimList{}
+
+	qeuryBuilder := r.pg.Builder.
+		Select(businessClaimColumns).
+		From("business_claims")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanBusinessClaim(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("business_claims").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Approve makes the claimant the owner of the business, promotes a plain user to business_owner
+// and rejects the other pending claims of the business, all in one transaction.
+// It returns pgx.ErrNoRows when the claim is no longer pending.
+func (r *BusinessClaimRepo) Approve(ctx context.Context, req entity.BusinessClaimDecision) (entity.BusinessClaim, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	businessID, userID, err := r.decide(ctx, tx, req, config.ClaimStatusApproved)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = r.addEvent(ctx, tx, req.ClaimID, req.ReviewerID, claimEventApproved, req.Note)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	qeury, args, err := r.pg.Builder.Update("businesses").
+		SetMap(map[string]interface{}{"owner_id": userID, "updated_at": "now()"}).
+		Where("id = ?", businessID).ToSql()
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	qeury, args, err = r.pg.Builder.Update("users").
+		SetMap(map[string]interface{}{"user_role": "business_owner", "updated_at": "now()"}).
+		Where("id = ? AND user_role = 'user'", userID).ToSql()
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	_, err = tx.Exec(ctx, rejectCompetingClaimsQuery,
+		req.ReviewerID, "Another claim of the business was approved", businessID, config.ClaimStatusRejected, config.ClaimStatusPending, claimEventRejected)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ClaimID})
+}
+
+// Reject closes the claim without changing the business. It returns pgx.ErrNoRows when the claim is no longer pending.
+func (r *BusinessClaimRepo) Reject(ctx context.Context, req entity.BusinessClaimDecision) (entity.BusinessClaim, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, _, err = r.decide(ctx, tx, req, config.ClaimStatusRejected)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = r.addEvent(ctx, tx, req.ClaimID, req.ReviewerID, claimEventRejected, req.Note)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ClaimID})
+}
+
+// decide moves a pending claim to the given status, returning its business_id and user_id
+func (r *BusinessClaimRepo) decide(ctx context.Context, tx pgx.Tx, req entity.BusinessClaimDecision, status string) (businessID, userID string, err error) {
+	qeury, args, err := r.pg.Builder.Update("business_claims").
+		SetMap(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": req.ReviewerID,
+			"note":        nullString(req.Note),
+			"updated_at":  "now()",
+		}).
+		Where("id = ? AND status = ?", req.ClaimID, config.ClaimStatusPending).
+		Suffix("RETURNING business_id, user_id").ToSql()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = tx.QueryRow(ctx, qeury, args...).Scan(&businessID, &userID)
+	return businessID, userID, err
+}
+
+func (r *BusinessClaimRepo) addEvent(ctx context.Context, tx pgx.Tx, claimID, actorID, action, note string) error {
+	qeury, args, err := r.pg.Builder.Insert("business_claim_events").
+		Columns(`id, claim_id, actor_id, action, note`).
+		Values(uuid.NewString(), claimID, actorID, action, nullString(note)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return fmt.Errorf("addEvent: %w", err)
+	}
+
+	return nil
+}
+
+const (
+	businessClaimColumns = `id, business_id, user_id, status, message, reviewed_by, note, created_at, updated_at`
+
+	// rejectCompetingClaimsQuery rejects the remaining pending claims of a business and records an event for each
+	rejectCompetingClaimsQuery = `
+		WITH rejected AS (
+			UPDATE business_claims SET status = $4, reviewed_by = $1, note = $2, updated_at = now()
+			WHERE business_id = $3 AND status = $5
+			RETURNING id
+		)
+		INSERT INTO business_claim_events (id, claim_id, actor_id, action, note)
+		SELECT gen_random_uuid(), id, $1, $6, $2 FROM rejected`
+)
+
+func scanBusinessClaim(row pgx.Row) (entity.BusinessClaim, error) {
+	var (
+		item                      entity.BusinessClaim
+		message, reviewedBy, note sql.NullString
+		createdAt, updatedAt      time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.Status, &message, &reviewedBy, &note, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	item.Message = message.String
+	item.ReviewedBy = reviewedBy.String
+	item.Note = note.String
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/abdulazizax/yelp/config"
 	"github.com/abdulazizax/yelp/internal/entity"
 	"github.com/abdulazizax/yelp/pkg/logger"
 	"github.com/abdulazizax/yelp/pkg/postgres"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 type BusinessRepo struct {
@@ -32,14 +34,42 @@ func NewBusinessRepo(pg *postgres.Postgres, config *config.Config, logger *logge
 func (r *BusinessRepo) Create(ctx context.Context, req entity.Business) (entity.Business, error) {
 	req.ID = uuid.NewString()
 
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+	if req.SpecialHours == nil {
+		req.SpecialHours = []entity.SpecialHours{}
+	}
+	if req.Attributes == nil {
+		req.Attributes = map[string]interface{}{}
+	}
+
 	qeury, args, err := r.pg.Builder.Insert("businesses").
-		Columns(`id, name, category_id, address, owner_id`).
-		Values(req.ID, req.Name, req.CategoryID, req.Address, req.OwnerID).ToSql()
+		Columns(`id, name, description, category_id, address, latitude, longitude, contact_info, hours_of_operation, special_hours, time_zone,
+			price_level, attributes, owner_id`).
+		Values(req.ID, req.Name, req.Description, req.CategoryID, req.Address, req.Latitude, req.Longitude,
+			req.ContactInfo, req.HoursOfOperation, req.SpecialHours, req.TimeZone, req.PriceLevel, req.Attributes, req.OwnerID).ToSql()
 	if err != nil {
 		return entity.Business{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Business{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	err = r.setCategories(ctx, tx, req.ID, req.CategoryIDs)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		return entity.Business{}, err
 	}
@@ -48,15 +78,10 @@ func (r *BusinessRepo) Create(ctx context.Context, req entity.Business) (entity.
 }
 
 func (r *BusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleRequest) (entity.Business, error) {
-	response := entity.Business{}
-	var (
-		createdAt, updatedAt                       time.Time
-		description, contactInfo, hoursOfOperation sql.NullString
-		latitude, longitude                        sql.NullFloat64
-	)
-
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, name, description, category_id, address, latitude, longitude, contact_info, hours_of_operation, owner_id, created_at, updated_at`).
+		Select(businessColumns).
+		Column(businessOpenNowColumn).
+		Column("NULL::float8 AS distance_m").
 		From("businesses")
 
 	switch {
@@ -75,59 +100,74 @@ func (r *BusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleR
 		return entity.Business{}, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
-		Scan(&response.ID, &response.Name, &description, &response.CategoryID, &response.Address,
-			&latitude, &longitude, &contactInfo, &hoursOfOperation, &response.OwnerID, &createdAt, &updatedAt)
-	if err != nil {
-		return entity.Business{}, err
+	return scanBusiness(r.pg.Pool.QueryRow(ctx, qeury, args...))
+}
+
+func (r *BusinessRepo) GetList(ctx context.Context, req entity.BusinessListFilter) (entity.BusinessList, error) {
+	var (
+		response       = entity.BusinessList{}
+		extraWhere     = squirrel.And{}
+		distanceColumn = squirrel.Expr("NULL::float8 AS distance_m")
+		expressions    = map[string]squirrel.Sqlizer{}
+	)
+
+	if req.Geo != nil {
+		// earth_box is served by the gist index, earth_distance trims the corners of the box
+		radius := req.Geo.RadiusKm * 1000
+		distance := squirrel.Expr("earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude))", req.Geo.Latitude, req.Geo.Longitude)
+		distanceColumn = squirrel.Alias(distance, "distance_m")
+		expressions["distance_m"] = distance
+		extraWhere = append(extraWhere,
+			squirrel.Expr("earth_box(ll_to_earth(?, ?), ?) @> ll_to_earth(latitude, longitude)", req.Geo.Latitude, req.Geo.Longitude, radius),
+			squirrel.Expr("earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude)) <= ?", req.Geo.Latitude, req.Geo.Longitude, radius),
+		)
 	}
 
-	response.CreatedAt = createdAt.Format(time.RFC3339)
-	response.UpdatedAt = updatedAt.Format(time.RFC3339)
-	if latitude.Valid {
-		response.Latitude = latitude.Float64
+	if box := req.BoundingBox; box != nil {
+		extraWhere = append(extraWhere, squirrel.Expr("latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude))
+		if box.MinLongitude <= box.MaxLongitude {
+			extraWhere = append(extraWhere, squirrel.Expr("longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude))
+		} else {
+			extraWhere = append(extraWhere, squirrel.Expr("(longitude >= ? OR longitude <= ?)", box.MinLongitude, box.MaxLongitude))
+		}
 	}
-	if longitude.Valid {
-		response.Longitude = longitude.Float64
+
+	if req.OpenAt != nil {
+		extraWhere = append(extraWhere,
+			squirrel.Expr("business_is_open(hours_of_operation, special_hours, time_zone, ?)", *req.OpenAt))
 	}
-	if contactInfo.Valid {
-		// JSON to struct (ContactInfo)
-		var contactInfoStruct entity.ContactInfo
-		err := json.Unmarshal([]byte(contactInfo.String), &contactInfoStruct)
-		if err != nil {
-			return response, err
-		}
-		response.ContactInfo = contactInfoStruct
+
+	if req.CategoryID != "" {
+		extraWhere = append(extraWhere, squirrel.Expr(`EXISTS (
+			SELECT 1 FROM business_category_links l
+			WHERE l.business_id = businesses.id AND l.category_id IN (`+categoryDescendantsCTE+` SELECT id FROM category_tree)
+		)`, req.CategoryID))
 	}
-	if hoursOfOperation.Valid {
-		// JSON to struct (HoursOfOperation)
-		var hoursOfOperationStruct entity.HoursOfOperation
-		err := json.Unmarshal([]byte(hoursOfOperation.String), &hoursOfOperationStruct)
+
+	if len(req.PriceLevels) > 0 {
+		extraWhere = append(extraWhere, squirrel.Eq{"price_level": req.PriceLevels})
+	}
+
+	if len(req.Attributes) > 0 {
+		// containment is served by the jsonb_path_ops gin index
+		attributes, err := json.Marshal(req.Attributes)
 		if err != nil {
 			return response, err
 		}
-		response.HoursOfOperation = hoursOfOperationStruct
+		extraWhere = append(extraWhere, squirrel.Expr("attributes @> ?::jsonb", string(attributes)))
 	}
-	if description.Valid {
-		response.Description = description.String
-	}
-
-	return response, nil
-}
-
-func (r *BusinessRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessList, error) {
-	var (
-		response                                   = entity.BusinessList{}
-		createdAt, updatedAt                       time.Time
-		description, contactInfo, hoursOfOperation sql.NullString
-		latitude, longitude                        sql.NullFloat64
-	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, name, description, category_id, address, latitude, longitude, contact_info, hours_of_operation, owner_id, created_at, updated_at`).
-		From("businesses")
+		Select(businessColumns).
+		Column(businessOpenNowColumn).
+		Column(distanceColumn).
+		From("businesses").
+		Where(extraWhere)
 
-	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+	qeuryBuilder, where, page, err := prepareKeysetQuery(qeuryBuilder, req.GetListFilter, "id", expressions)
+	if err != nil {
+		return response, err
+	}
 
 	qeury, args, err := qeuryBuilder.ToSql()
 	if err != nil {
@@ -141,52 +181,27 @@ func (r *BusinessRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 	defer rows.Close()
 
 	for rows.Next() {
-		var item entity.Business
-		err = rows.Scan(&item.ID, &item.Name, &description, &item.CategoryID, &item.Address,
-			&latitude, &longitude, &contactInfo, &hoursOfOperation, &item.OwnerID, &createdAt, &updatedAt)
+		item, err := scanBusiness(page.Row(rows))
 		if err != nil {
 			return response, err
 		}
 
-		item.CreatedAt = createdAt.Format(time.RFC3339)
-		item.UpdatedAt = updatedAt.Format(time.RFC3339)
-		if latitude.Valid {
-			item.Latitude = latitude.Float64
-		}
-		if longitude.Valid {
-			item.Longitude = longitude.Float64
-		}
-		if contactInfo.Valid {
-			// JSON to struct (ContactInfo)
-			var contactInfoStruct entity.ContactInfo
-			err := json.Unmarshal([]byte(contactInfo.String), &contactInfoStruct)
-			if err != nil {
-				return response, err
-			}
-			item.ContactInfo = contactInfoStruct
-		}
-		if hoursOfOperation.Valid {
-			// JSON to struct (HoursOfOperation)
-			var hoursOfOperationStruct entity.HoursOfOperation
-			err := json.Unmarshal([]byte(hoursOfOperation.String), &hoursOfOperationStruct)
-			if err != nil {
-				return response, err
-			}
-			item.HoursOfOperation = hoursOfOperationStruct
-		}
-		if description.Valid {
-			item.Description = description.String
-		}
-
 		response.Items = append(response.Items, item)
 	}
 
-	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("businesses").Where(where).ToSql()
+	response.Items, response.Page = finishKeyset(page, response.Items)
+
+	if req.SkipCount {
+		return response, nil
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("businesses").Where(extraWhere).Where(where).ToSql()
 	if err != nil {
 		return response, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	response.Count = new(int)
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(response.Count)
 	if err != nil {
 		return response, err
 	}
@@ -204,6 +219,10 @@ func (r *BusinessRepo) Update(ctx context.Context, req entity.Business) (entity.
 		"longitude":          req.Longitude,
 		"contact_info":       req.ContactInfo,
 		"hours_of_operation": req.HoursOfOperation,
+		"special_hours":      req.SpecialHours,
+		"time_zone":          req.TimeZone,
+		"price_level":        req.PriceLevel,
+		"attributes":         req.Attributes,
 		"owner_id":           req.OwnerID,
 		"updated_at":         "now()",
 	}
@@ -213,7 +232,23 @@ func (r *BusinessRepo) Update(ctx context.Context, req entity.Business) (entity.
 		return entity.Business{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Business{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	err = r.setCategories(ctx, tx, req.ID, req.CategoryIDs)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		return entity.Business{}, err
 	}
@@ -257,3 +292,112 @@ func (r *BusinessRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRe
 
 	return response, nil
 }
+
+// setCategories replaces the categories linked to a business
+func (r *BusinessRepo) setCategories(ctx context.Context, tx pgx.Tx, businessID string, categoryIDs []string) error {
+	qeury, args, err := r.pg.Builder.Delete("business_category_links").Where("business_id = ?", businessID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	insert := r.pg.Builder.Insert("business_category_links").Columns("business_id, category_id")
+	for _, categoryID := range categoryIDs {
+		insert = insert.Values(businessID, categoryID)
+	}
+
+	qeury, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	return err
+}
+
+// businessColumns are scanned by scanBusiness, followed by the is_open_now and distance_m columns
+const (
+	businessColumns = `id, name, description, category_id, address, latitude, longitude, contact_info, hours_of_operation,
+		special_hours, time_zone, price_level, attributes, owner_id, avg_rating, review_count, rating_histogram, check_in_count, created_at, updated_at,
+		ARRAY(SELECT l.category_id::text FROM business_category_links l WHERE l.business_id = businesses.id
+			ORDER BY l.category_id = businesses.category_id DESC, l.category_id) AS category_ids`
+	businessOpenNowColumn = "business_is_open(hours_of_operation, special_hours, time_zone, now()) AS is_open_now"
+)
+
+func scanBusiness(row pgx.Row) (entity.Business, error) {
+	var (
+		item                                                                 entity.Business
+		createdAt, updatedAt                                                 time.Time
+		description, contactInfo, hoursOfOperation, specialHours, attributes sql.NullString
+		priceLevel                                                           sql.NullInt32
+		latitude, longitude, distance                                        sql.NullFloat64
+		ratingHistogram                                                      []int32
+	)
+
+	err := row.Scan(&item.ID, &item.Name, &description, &item.CategoryID, &item.Address,
+		&latitude, &longitude, &contactInfo, &hoursOfOperation, &specialHours, &item.TimeZone, &priceLevel, &attributes, &item.OwnerID,
+		&item.AvgRating, &item.ReviewCount, &ratingHistogram, &item.CheckInCount, &createdAt, &updatedAt, &item.CategoryIDs, &item.IsOpenNow, &distance)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+	item.RatingHistogram = newRatingHistogram(ratingHistogram)
+	item.Description = description.String
+	if latitude.Valid && longitude.Valid {
+		item.Latitude, item.Longitude = &latitude.Float64, &longitude.Float64
+	}
+	item.SpecialHours = []entity.SpecialHours{}
+	if contactInfo.Valid {
+		if err := json.Unmarshal([]byte(contactInfo.String), &item.ContactInfo); err != nil {
+			return item, err
+		}
+	}
+	if hoursOfOperation.Valid {
+		if err := json.Unmarshal([]byte(hoursOfOperation.String), &item.HoursOfOperation); err != nil {
+			return item, err
+		}
+	}
+	if specialHours.Valid {
+		if err := json.Unmarshal([]byte(specialHours.String), &item.SpecialHours); err != nil {
+			return item, err
+		}
+	}
+	if attributes.Valid {
+		if err := json.Unmarshal([]byte(attributes.String), &item.Attributes); err != nil {
+			return item, err
+		}
+	}
+	if priceLevel.Valid {
+		level := int(priceLevel.Int32)
+		item.PriceLevel = &level
+	}
+	if distance.Valid {
+		distanceM := distance.Float64
+		item.DistanceM = &distanceM
+	}
+
+	return item, nil
+}
+
+// newRatingHistogram converts the rating_histogram array (index 0 holds 1 star reviews) into a RatingHistogram
+func newRatingHistogram(counts []int32) entity.RatingHistogram {
+	histogram := entity.RatingHistogram{}
+	for star := 1; star <= 5; star++ {
+		histogram[star] = 0
+		if star <= len(counts) {
+			histogram[star] = int(counts[star-1])
+		}
+	}
+
+	return histogram
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type CheckInRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewCheckInRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CheckInRepo {
+	return &CheckInRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create records a check-in unless the user already checked in to the business within the configured cooldown.
+// It returns pgx.ErrNoRows when the cooldown has not passed yet.
+func (r *CheckInRepo) Create(ctx context.Context, req entity.CheckIn) (entity.CheckIn, error) {
+	req.ID = uuid.NewString()
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// concurrent check-ins of the same user to the same business wait for each other, so only one passes the cooldown check
+	_, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", req.UserID+req.BusinessID)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	qeury, args, err := r.pg.Builder.Insert("check_ins").
+		Columns(`id, user_id, business_id, latitude, longitude, distance_m`).
+		Select(r.pg.Builder.
+			Select().
+			Column("?, ?, ?, ?::float8, ?::float8, ?::float8", req.ID, req.UserID, req.BusinessID, req.Latitude, req.Longitude, req.DistanceM).
+			Where("NOT EXISTS (SELECT 1 FROM check_ins WHERE user_id = ? AND business_id = ? AND created_at > now() - ?::interval)",
+				req.UserID, req.BusinessID, r.config.CheckIn.Cooldown)).ToSql()
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	n, err := tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.CheckIn{}, pgx.ErrNoRows
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *CheckInRepo) GetSingle(ctx context.Context, req entity.Id) (entity.CheckIn, error) {
+	qeury, args, err := r.pg.Builder.
+		Select(checkInColumns).
+		From("check_ins c").
+		Join("businesses b ON b.id = c.business_id").
+		Where("c.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	return scanCheckIn(r.pg.Pool.QueryRow(ctx, qeury, args...))
+}
+
+func (r *CheckInRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CheckInList, error) {
+	response := entity.CheckInList{}
+
+	qeuryBuilder := r.pg.Builder.
+		Select(checkInColumns).
+		From("check_ins c").
+		Join("businesses b ON b.id = c.business_id")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanCheckIn(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("check_ins c").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+const checkInColumns = `c.id, c.user_id, c.business_id, b.name, c.latitude, c.longitude, c.distance_m, c.created_at`
+
+func scanCheckIn(row pgx.Row) (entity.CheckIn, error) {
+	var (
+		item                          entity.CheckIn
+		latitude, longitude, distance sql.NullFloat64
+		createdAt                     time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.UserID, &item.BusinessID, &item.BusinessName, &latitude, &longitude, &distance, &createdAt)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	if latitude.Valid && longitude.Valid {
+		item.Latitude, item.Longitude = &latitude.Float64, &longitude.Float64
+	}
+	if distance.Valid {
+		item.DistanceM = &distance.Float64
+	}
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// testPostgres connects to the migrated database of PG_URL, tests that need one are skipped without it
+func testPostgres(t *testing.T) *postgres.Postgres {
+	t.Helper()
+
+	url := os.Getenv("PG_URL")
+	if url == "" {
+		t.Skip("PG_URL is not set")
+	}
+
+	pg, err := postgres.New(url, postgres.ConnAttempts(1))
+	if err != nil {
+		t.Fatalf("postgres.New() error = %v", err)
+	}
+	t.Cleanup(pg.Close)
+
+	return pg
+}
+
+func TestCheckInCooldown(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		pg         = testPostgres(t)
+		userID     = uuid.NewString()
+		categoryID = uuid.NewString()
+		businessID = uuid.NewString()
+	)
+
+	cfg := &config.Config{}
+	cfg.CheckIn.Cooldown = 4 * time.Hour
+	r := NewCheckInRepo(pg, cfg, logger.New("error"))
+
+	for _, qeury := range []string{
+		fmt.Sprintf(`INSERT INTO users (id, user_type, user_role, full_name, username, email, password)
+			VALUES ('%[1]s', 'user', 'user', 'Check In', '%[1]s', '%[1]s@example.com', 'secret')`, userID),
+		fmt.Sprintf(`INSERT INTO business_categories (id, name) VALUES ('%[1]s', '%[1]s')`, categoryID),
+		fmt.Sprintf(`INSERT INTO businesses (id, name, category_id, address, owner_id)
+			VALUES ('%s', 'Cafe', '%s', 'Main street', '%s')`, businessID, categoryID, userID),
+	} {
+		if _, err := pg.Pool.Exec(ctx, qeury); err != nil {
+			t.Fatalf("Exec() error = %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		pg.Pool.Exec(ctx, "DELETE FROM businesses WHERE id = $1", businessID)
+		pg.Pool.Exec(ctx, "DELETE FROM business_categories WHERE id = $1", categoryID)
+		pg.Pool.Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
+	})
+
+	req := entity.CheckIn{UserID: userID, BusinessID: businessID}
+
+	first, err := r.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("first Create() error = %v", err)
+	}
+
+	// a default frozen at migration time would put the check-in outside any cooldown
+	var recent bool
+	err = pg.Pool.QueryRow(ctx, "SELECT created_at BETWEEN now() - interval '1 minute' AND now() FROM check_ins WHERE id = $1",
+		first.ID).Scan(&recent)
+	if err != nil {
+		t.Fatalf("QueryRow() error = %v", err)
+	}
+	if !recent {
+		t.Error("check-in was not stamped with the insert time")
+	}
+
+	if _, err := r.Create(ctx, req); err != pgx.ErrNoRows {
+		t.Errorf("second Create() error = %v, want %v", err, pgx.ErrNoRows)
+	}
+
+	_, err = pg.Pool.Exec(ctx, "UPDATE check_ins SET created_at = now() - $2::interval - interval '1 minute' WHERE id = $1",
+		first.ID, cfg.CheckIn.Cooldown)
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	if _, err := r.Create(ctx, req); err != nil {
+		t.Errorf("Create() after the cooldown error = %v", err)
+	}
+}
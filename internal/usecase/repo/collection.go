@@ -0,0 +1,292 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type CollectionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewCollectionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CollectionRepo {
+	return &CollectionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, req entity.Collection) (entity.Collection, error) {
+	req.ID = uuid.NewString()
+
+	qeury, args, err := r.pg.Builder.Insert("collections").
+		Columns(`id, user_id, name, description, is_public, is_default`).
+		Values(req.ID, req.UserID, req.Name, nullString(req.Description), req.IsPublic, req.IsDefault).ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return r.GetSingle(ctx, entity.CollectionSingleRequest{ID: req.ID})
+}
+
+// GetDefault returns the bookmarks collection of the user, creating it on first use
+func (r *CollectionRepo) GetDefault(ctx context.Context, req entity.Id) (entity.Collection, error) {
+	qeury, args, err := r.pg.Builder.Insert("collections").
+		Columns(`id, user_id, name, is_default`).
+		Values(uuid.NewString(), req.ID, config.DefaultCollectionName, true).
+		Suffix("ON CONFLICT (user_id) WHERE is_default DO NOTHING").ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return r.GetSingle(ctx, entity.CollectionSingleRequest{UserID: req.ID, Default: true})
+}
+
+func (r *CollectionRepo) GetSingle(ctx context.Context, req entity.CollectionSingleRequest) (entity.Collection, error) {
+	qeuryBuilder := r.pg.Builder.
+		Select(collectionColumns).
+		From("collections")
+
+	switch {
+	case req.ID != "":
+		qeuryBuilder = qeuryBuilder.Where("id = ?", req.ID)
+	case req.UserID != "" && req.Default:
+		qeuryBuilder = qeuryBuilder.Where("user_id = ? AND is_default", req.UserID)
+	default:
+		return entity.Collection{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return scanCollection(r.pg.Pool.QueryRow(ctx, qeury, args...))
+}
+
+func (r *CollectionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionList, error) {
+	response := entity.CollectionList{}
+
+	qeuryBuilder := r.pg.Builder.
+		Select(collectionColumns).
+		From("collections")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanCollection(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("collections").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, req entity.Collection) (entity.Collection, error) {
+	mp := map[string]interface{}{
+		"name":        req.Name,
+		"description": nullString(req.Description),
+		"is_public":   req.IsPublic,
+		"updated_at":  "now()",
+	}
+
+	qeury, args, err := r.pg.Builder.Update("collections").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return r.GetSingle(ctx, entity.CollectionSingleRequest{ID: req.ID})
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, req entity.Id) error {
+	qeury, args, err := r.pg.Builder.Delete("collections").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) GetItems(ctx context.Context, req entity.Id) ([]entity.CollectionItem, error) {
+	var (
+		response  = []entity.CollectionItem{}
+		createdAt time.Time
+		note      sql.NullString
+	)
+
+	qeury, args, err := r.pg.Builder.
+		Select(`ci.business_id, b.name, ci.position, ci.note, ci.created_at`).
+		From("collection_items ci").
+		Join("businesses b ON b.id = ci.business_id").
+		Where("ci.collection_id = ?", req.ID).
+		OrderBy("ci.position").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.CollectionItem
+		err = rows.Scan(&item.BusinessID, &item.BusinessName, &item.Position, &note, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Note = note.String
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// AddItem appends a business to the end of the collection
+func (r *CollectionRepo) AddItem(ctx context.Context, req entity.CollectionItemRequest) error {
+	qeury, args, err := r.pg.Builder.Insert("collection_items").
+		Columns(`collection_id, business_id, position, note`).
+		Values(req.CollectionID, req.BusinessID,
+			r.pg.Builder.Select("COALESCE(MAX(position), 0) + 1").From("collection_items").Where("collection_id = ?", req.CollectionID).Prefix("(").Suffix(")"),
+			nullString(req.Note)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(ctx, req.CollectionID)
+}
+
+func (r *CollectionRepo) RemoveItem(ctx context.Context, req entity.CollectionItemRequest) error {
+	qeury, args, err := r.pg.Builder.Delete("collection_items").
+		Where("collection_id = ? AND business_id = ?", req.CollectionID, req.BusinessID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return r.touch(ctx, req.CollectionID)
+}
+
+// Reorder numbers the businesses of the collection in the order of req.BusinessIDs
+func (r *CollectionRepo) Reorder(ctx context.Context, req entity.CollectionOrderRequest) error {
+	qeury, args, err := r.pg.Builder.Update("collection_items").
+		Set("position", squirrel.Expr("array_position(?::uuid[], business_id)", req.BusinessIDs)).
+		Where("collection_id = ?", req.CollectionID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(ctx, req.CollectionID)
+}
+
+func (r *CollectionRepo) touch(ctx context.Context, collectionID string) error {
+	qeury, args, err := r.pg.Builder.Update("collections").Set("updated_at", "now()").Where("id = ?", collectionID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	return err
+}
+
+const collectionColumns = `id, user_id, name, description, is_public, is_default,
+	(SELECT COUNT(1) FROM collection_items ci WHERE ci.collection_id = collections.id), created_at, updated_at`
+
+func scanCollection(row pgx.Row) (entity.Collection, error) {
+	var (
+		item                 entity.Collection
+		description          sql.NullString
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &description, &item.IsPublic, &item.IsDefault, &item.ItemCount, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	item.Description = description.String
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
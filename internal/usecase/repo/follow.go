@@ -0,0 +1,230 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/jackc/pgx/v4"
+)
+
+type FollowRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewFollowRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *FollowRepo {
+	return &FollowRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create follows a user, following someone twice is not an error
+func (r *FollowRepo) Create(ctx context.Context, req entity.FollowRequest) error {
+	qeury, args, err := r.pg.Builder.Insert("user_follows").
+		Columns(`follower_id, followee_id`).
+		Values(req.FollowerID, req.FolloweeID).
+		Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	return err
+}
+
+func (r *FollowRepo) Delete(ctx context.Context, req entity.FollowRequest) error {
+	qeury, args, err := r.pg.Builder.Delete("user_follows").
+		Where("follower_id = ? AND followee_id = ?", req.FollowerID, req.FolloweeID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetFollowers lists the users following req.UserID, newest first
+func (r *FollowRepo) GetFollowers(ctx context.Context, req entity.FollowListRequest) (entity.FollowList, error) {
+	return r.getList(ctx, req, "follower_id", "followee_id")
+}
+
+// GetFollowing lists the users req.UserID follows, newest first
+func (r *FollowRepo) GetFollowing(ctx context.Context, req entity.FollowListRequest) (entity.FollowList, error) {
+	return r.getList(ctx, req, "followee_id", "follower_id")
+}
+
+// getList lists the users in userColumn of the follows whose filterColumn is req.UserID
+func (r *FollowRepo) getList(ctx context.Context, req entity.FollowListRequest, userColumn, filterColumn string) (entity.FollowList, error) {
+	var (
+		response       = entity.FollowList{}
+		followedAt     time.Time
+		profilePicture sql.NullString
+	)
+
+	qeuryBuilder := r.pg.Builder.
+		Select(`u.id, u.full_name, u.username, u.profile_picture, f.created_at`).
+		From("user_follows f").
+		Join("users u ON u.id = f."+userColumn).
+		Where("f."+filterColumn+" = ?", req.UserID).
+		OrderBy("f.created_at DESC", "f."+userColumn+" DESC")
+
+	qeuryBuilder, _ = PrepareGetListQuery(qeuryBuilder, entity.GetListFilter{Page: req.Page, Limit: req.Limit})
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.FollowUser
+		err = rows.Scan(&item.ID, &item.FullName, &item.Username, &profilePicture, &followedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.ProfilePicture = profilePicture.String
+		item.FollowedAt = followedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("user_follows").Where(filterColumn+" = ?", req.UserID).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// feedQuery merges the published reviews of the followed users with the photos attached to them, newest first.
+// The feed is assembled on read, each branch is cut at the cursor ($2, $3) before the merge.
+// created_at is selected again as text for the cursor, so it compares exactly against the stored value.
+const feedQuery = `
+	WITH followees AS (
+		SELECT followee_id FROM user_follows WHERE follower_id = $1
+	)
+	SELECT type, id, review_id, user_id, full_name, business_id, business_name, rating, comment, filepath, content_type, variants, created_at, created_at::text
+	FROM (
+		(
+			SELECT $5::text AS type, r.id, r.id AS review_id, r.user_id, u.full_name, r.business_id, b.name AS business_name, r.rating, r.comment,
+				NULL::text AS filepath, NULL::text AS content_type, NULL::jsonb AS variants, r.created_at
+			FROM reviews r
+			JOIN users u ON u.id = r.user_id
+			JOIN businesses b ON b.id = r.business_id
+			WHERE r.user_id IN (SELECT followee_id FROM followees) AND r.status = $7
+				AND ($2::timestamp IS NULL OR (r.created_at, r.id) < ($2, $3::uuid))
+			ORDER BY r.created_at DESC, r.id DESC
+			LIMIT $4
+		)
+		UNION ALL
+		(
+			SELECT $6::text, a.id, r.id, r.user_id, u.full_name, r.business_id, b.name, r.rating, NULL,
+				a.filepath, a.content_type::text, a.variants, a.created_at
+			FROM review_attachments a
+			JOIN reviews r ON r.id = a.review_id
+			JOIN users u ON u.id = r.user_id
+			JOIN businesses b ON b.id = r.business_id
+			WHERE r.user_id IN (SELECT followee_id FROM followees) AND r.status = $7 AND a.content_type = 'photo'
+				AND ($2::timestamp IS NULL OR (a.created_at, a.id) < ($2, $3::uuid))
+			ORDER BY a.created_at DESC, a.id DESC
+			LIMIT $4
+		)
+	) feed
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4`
+
+// GetFeed returns a page of the activity of the users req.UserID follows, Next is set when there are older items
+func (r *FollowRepo) GetFeed(ctx context.Context, req entity.FeedRequest) (entity.FeedPage, error) {
+	var (
+		response  = entity.FeedPage{Items: []entity.FeedItem{}}
+		afterTime *string
+		afterID   *string
+		last      []string
+	)
+
+	if req.Cursor != nil {
+		if len(req.Cursor.Values) != 2 {
+			return response, errors.New("cursor does not match the order of the feed")
+		}
+		afterTime, afterID = &req.Cursor.Values[0], &req.Cursor.Values[1]
+	}
+
+	// one extra row tells if there is a next page
+	rows, err := r.pg.Pool.Query(ctx, feedQuery, req.UserID, afterTime, afterID, req.Limit+1,
+		config.FeedItemReview, config.FeedItemPhoto, config.ReviewStatusPublished)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item                           entity.FeedItem
+			comment, filepath, contentType sql.NullString
+			variants                       []byte
+			createdAt                      time.Time
+			position                       string
+		)
+
+		err = rows.Scan(&item.Type, &item.ID, &item.ReviewID, &item.UserID, &item.UserFullName, &item.BusinessID, &item.BusinessName,
+			&item.Rating, &comment, &filepath, &contentType, &variants, &createdAt, &position)
+		if err != nil {
+			return response, err
+		}
+
+		if len(response.Items) == req.Limit {
+			response.Next = &entity.PageCursor{Order: config.FeedOrder, Values: last}
+			break
+		}
+
+		item.Comment = comment.String
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		if item.Type == config.FeedItemPhoto {
+			item.Photo = &entity.ReviewAttachment{
+				Id:          item.ID,
+				ReviewId:    item.ReviewID,
+				FilePath:    filepath.String,
+				ContentType: contentType.String,
+				CreatedAt:   item.CreatedAt,
+			}
+			item.Photo.Variants, err = variantsFromJSON(variants)
+			if err != nil {
+				return response, err
+			}
+		}
+
+		last = []string{position, item.ID}
+		response.Items = append(response.Items, item)
+	}
+
+	return response, rows.Err()
+}
@@ -1,15 +1,28 @@
 package repo
 
 import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
 	"github.com/Masterminds/squirrel"
 	"github.com/abdulazizax/yelp/internal/entity"
 )
 
+// columnPattern matches the column names filters and orders may use, they are written into the SQL as they are
+var columnPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
 func PrepareFilter(filters []entity.Filter) squirrel.And {
 	where := squirrel.And{}
 	or := squirrel.Or{}
 
 	for _, e := range filters {
+		if !columnPattern.MatchString(e.Column) {
+			// a filter that can not be applied must not widen the result
+			where = append(where, squirrel.Expr("FALSE"))
+			continue
+		}
+
 		switch e.Type {
 		case "eq":
 			where = append(where, squirrel.Eq{e.Column: e.Value})
@@ -25,6 +38,19 @@ func PrepareFilter(filters []entity.Filter) squirrel.And {
 			where = append(where, squirrel.LtOrEq{e.Column: e.Value})
 		case "search":
 			or = append(or, squirrel.ILike{e.Column: "%" + e.Value + "%"})
+		case "contains":
+			// unlike search every contains filter must match
+			where = append(where, squirrel.ILike{e.Column: "%" + likeEscape(e.Value) + "%"})
+		case "fts":
+			// full-text match against a tsvector column, ranked queries live in SearchRepo
+			if e.Value != "" {
+				or = append(or, squirrel.Expr(e.Column+" @@ websearch_to_tsquery('english', ?)", e.Value))
+			}
+		case "similar":
+			// trigram similarity, tolerates typos
+			if e.Value != "" {
+				or = append(or, squirrel.Expr(e.Column+" % ?", e.Value))
+			}
 		}
 	}
 
@@ -41,6 +67,9 @@ func PrepareGetListQuery(selectQuery squirrel.SelectBuilder, filterRequest entit
 	selectQuery = selectQuery.Where(where)
 
 	for _, e := range filterRequest.OrderBy {
+		if !validOrderBy(e) {
+			continue
+		}
 		selectQuery = selectQuery.OrderBy(e.Column + " " + e.Order)
 	}
 
@@ -56,3 +85,43 @@ func PrepareGetListQuery(selectQuery squirrel.SelectBuilder, filterRequest entit
 
 	return selectQuery, where
 }
+
+func validOrderBy(e entity.OrderBy) bool {
+	return columnPattern.MatchString(e.Column) && (e.Order == "asc" || e.Order == "desc")
+}
+
+// variantsToJSON prepares attachment variants for a JSONB column, storing NULL when there are none
+func variantsToJSON(variants map[string]string) interface{} {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	js, _ := json.Marshal(variants)
+	return string(js)
+}
+
+func variantsFromJSON(js []byte) (map[string]string, error) {
+	if len(js) == 0 {
+		return nil, nil
+	}
+
+	variants := map[string]string{}
+	err := json.Unmarshal(js, &variants)
+	return variants, err
+}
+
+// nullString stores empty strings as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeEscape makes user input match literally inside a LIKE pattern
+func likeEscape(s string) string {
+	return likeEscaper.Replace(s)
+}
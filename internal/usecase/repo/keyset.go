@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/jackc/pgx/v4"
+)
+
+// keyset pages a list by the values of its ORDER BY columns. The values of every row are selected as
+// trailing text columns, so a cursor made from them compares exactly against the stored values.
+type keyset struct {
+	req    entity.GetListFilter
+	keys   []keysetKey
+	values [][]string
+}
+
+type keysetKey struct {
+	expr squirrel.Sqlizer
+	desc bool
+}
+
+// prepareKeysetQuery is PrepareGetListQuery for lists that hand out cursors. idColumn breaks ties between rows
+// with the same order values, expressions give the SQL of order columns that are select aliases, such as a distance.
+// The order columns must not be NULL. Scan the rows through keyset.Row and pass the items to finishKeyset.
+func prepareKeysetQuery(selectQuery squirrel.SelectBuilder, req entity.GetListFilter, idColumn string,
+	expressions map[string]squirrel.Sqlizer) (squirrel.SelectBuilder, squirrel.And, *keyset, error) {
+	where := PrepareFilter(req.Filters)
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	page := &keyset{req: req}
+	backward := req.Cursor != nil && req.Cursor.Backward
+
+	tieBreaker := entity.OrderBy{Column: idColumn, Order: "desc"}
+	if len(req.OrderBy) > 0 {
+		tieBreaker.Order = req.OrderBy[len(req.OrderBy)-1].Order
+	}
+
+	for _, e := range append(slices.Clone(req.OrderBy), tieBreaker) {
+		if !validOrderBy(e) {
+			return selectQuery, where, nil, fmt.Errorf("invalid order %q %q", e.Column, e.Order)
+		}
+
+		expr, ok := expressions[e.Column]
+		if !ok {
+			expr = squirrel.Expr(e.Column)
+		}
+
+		sql, args, err := expr.ToSql()
+		if err != nil {
+			return selectQuery, where, nil, err
+		}
+
+		desc := e.Order == "desc"
+		page.keys = append(page.keys, keysetKey{expr: expr, desc: desc})
+
+		// going backward reads the rows before the cursor from the nearest one
+		order := "ASC"
+		if desc != backward {
+			order = "DESC"
+		}
+
+		selectQuery = selectQuery.Column(squirrel.Expr("("+sql+")::text", args...)).OrderBy(e.Column + " " + order)
+	}
+
+	selectQuery = selectQuery.Where(where).Limit(uint64(req.Limit + 1))
+
+	if req.Cursor == nil {
+		return selectQuery.Offset(uint64((req.Page - 1) * req.Limit)), where, page, nil
+	}
+
+	after, err := page.after(req.Cursor.Values, backward)
+	if err != nil {
+		return selectQuery, where, nil, err
+	}
+
+	return selectQuery.Where(after), where, page, nil
+}
+
+// after matches the rows that come after values in the order of the query, which is reversed when going backward
+func (k *keyset) after(values []string, backward bool) (squirrel.Sqlizer, error) {
+	if len(values) != len(k.keys) {
+		return nil, errors.New("cursor does not match the order of the list")
+	}
+
+	// (a, b) after (1, 2) is a > 1 OR (a = 1 AND b > 2), the columns may be sorted in different directions
+	or := squirrel.Or{}
+	for i, key := range k.keys {
+		and := squirrel.And{}
+		for j := 0; j < i; j++ {
+			and = append(and, k.compare(j, "=", values[j]))
+		}
+
+		op := ">"
+		if key.desc != backward {
+			op = "<"
+		}
+		or = append(or, append(and, k.compare(i, op, values[i])))
+	}
+
+	return or, nil
+}
+
+func (k *keyset) compare(i int, op, value string) squirrel.Sqlizer {
+	sql, args, _ := k.keys[i].expr.ToSql()
+
+	return squirrel.Expr(sql+" "+op+" ?", append(slices.Clone(args), value)...)
+}
+
+// Row scans a row of the list and keeps its order values for the cursors
+func (k *keyset) Row(rows pgx.Rows) pgx.Row {
+	return keysetRow{rows: rows, page: k}
+}
+
+type keysetRow struct {
+	rows pgx.Rows
+	page *keyset
+}
+
+func (r keysetRow) Scan(dest ...interface{}) error {
+	values := make([]string, len(r.page.keys))
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+
+	err := r.rows.Scan(dest...)
+	if err != nil {
+		return err
+	}
+
+	r.page.values = append(r.page.values, values)
+	return nil
+}
+
+// finishKeyset drops the extra row read to learn if there is a next page, puts a backward page back
+// in list order and sets the cursors of the pages around it
+func finishKeyset[T any](k *keyset, items []T) ([]T, entity.Page) {
+	var (
+		page     entity.Page
+		more     = len(items) > k.req.Limit
+		backward = k.req.Cursor != nil && k.req.Cursor.Backward
+		values   = k.values
+	)
+
+	if more {
+		items, values = items[:k.req.Limit], values[:k.req.Limit]
+	}
+
+	if backward {
+		slices.Reverse(items)
+		slices.Reverse(values)
+	}
+
+	if len(items) == 0 {
+		return items, page
+	}
+
+	order := k.req.OrderKey()
+	first, last := values[0], values[len(values)-1]
+
+	// a backward page always has the page it came from after it
+	hasNext, hasPrev := more || backward, k.req.Cursor != nil || k.req.Page > 1
+	if backward {
+		hasPrev = more
+	}
+
+	if hasNext {
+		page.Next = &entity.PageCursor{Order: order, Values: last}
+	}
+	if hasPrev {
+		page.Prev = &entity.PageCursor{Order: order, Values: first, Backward: true}
+	}
+
+	return items, page
+}
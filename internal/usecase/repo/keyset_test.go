@@ -0,0 +1,341 @@
+package repo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/jackc/pgx/v4"
+)
+
+func TestPrepareKeysetQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         entity.GetListFilter
+		expressions map[string]squirrel.Sqlizer
+		wantSQL     string
+		wantArgs    []interface{}
+		wantErr     bool
+	}{
+		{
+			name:    "offset page",
+			req:     entity.GetListFilter{Page: 3, Limit: 10, OrderBy: []entity.OrderBy{{Column: "created_at", Order: "desc"}}},
+			wantSQL: "SELECT id, (created_at)::text, (id)::text FROM users WHERE (1=1) ORDER BY created_at DESC, id DESC LIMIT 11 OFFSET 20",
+		},
+		{
+			name:    "defaults",
+			req:     entity.GetListFilter{},
+			wantSQL: "SELECT id, (id)::text FROM users WHERE (1=1) ORDER BY id DESC LIMIT 11 OFFSET 0",
+		},
+		{
+			name: "mixed directions forward",
+			req: entity.GetListFilter{
+				Limit:   5,
+				OrderBy: []entity.OrderBy{{Column: "rating", Order: "asc"}, {Column: "created_at", Order: "desc"}},
+				Cursor:  &entity.PageCursor{Values: []string{"4", "2024-05-01 10:00:00", "id-1"}},
+			},
+			wantSQL: "SELECT id, (rating)::text, (created_at)::text, (id)::text FROM users " +
+				"WHERE (1=1) AND ((rating > ?) OR (rating = ? AND created_at < ?) OR (rating = ? AND created_at = ? AND id < ?)) " +
+				"ORDER BY rating ASC, created_at DESC, id DESC LIMIT 6",
+			wantArgs: []interface{}{"4", "4", "2024-05-01 10:00:00", "4", "2024-05-01 10:00:00", "id-1"},
+		},
+		{
+			name: "mixed directions backward",
+			req: entity.GetListFilter{
+				Limit:   5,
+				OrderBy: []entity.OrderBy{{Column: "rating", Order: "asc"}, {Column: "created_at", Order: "desc"}},
+				Cursor:  &entity.PageCursor{Values: []string{"4", "2024-05-01 10:00:00", "id-1"}, Backward: true},
+			},
+			wantSQL: "SELECT id, (rating)::text, (created_at)::text, (id)::text FROM users " +
+				"WHERE (1=1) AND ((rating < ?) OR (rating = ? AND created_at > ?) OR (rating = ? AND created_at = ? AND id > ?)) " +
+				"ORDER BY rating DESC, created_at ASC, id ASC LIMIT 6",
+			wantArgs: []interface{}{"4", "4", "2024-05-01 10:00:00", "4", "2024-05-01 10:00:00", "id-1"},
+		},
+		{
+			name: "ascending list breaks ties by ascending id",
+			req: entity.GetListFilter{
+				Limit:   5,
+				OrderBy: []entity.OrderBy{{Column: "full_name", Order: "asc"}},
+				Cursor:  &entity.PageCursor{Values: []string{"Aziz", "id-1"}},
+			},
+			wantSQL: "SELECT id, (full_name)::text, (id)::text FROM users " +
+				"WHERE (1=1) AND ((full_name > ?) OR (full_name = ? AND id > ?)) ORDER BY full_name ASC, id ASC LIMIT 6",
+			wantArgs: []interface{}{"Aziz", "Aziz", "id-1"},
+		},
+		{
+			name: "filters and an expression",
+			req: entity.GetListFilter{
+				Limit:   5,
+				Filters: []entity.Filter{{Column: "status", Type: "eq", Value: "active"}},
+				OrderBy: []entity.OrderBy{{Column: "distance_m", Order: "asc"}},
+				Cursor:  &entity.PageCursor{Values: []string{"120.5", "id-1"}},
+			},
+			expressions: map[string]squirrel.Sqlizer{"distance_m": squirrel.Expr("distance(location, ?)", "point")},
+			wantSQL: "SELECT id, (distance(location, ?))::text, (id)::text FROM users " +
+				"WHERE (status = ?) AND ((distance(location, ?) > ?) OR (distance(location, ?) = ? AND id > ?)) " +
+				"ORDER BY distance_m ASC, id ASC LIMIT 6",
+			wantArgs: []interface{}{"point", "active", "point", "120.5", "point", "120.5", "id-1"},
+		},
+		{
+			name: "cursor of another sort",
+			req: entity.GetListFilter{
+				OrderBy: []entity.OrderBy{{Column: "rating", Order: "asc"}, {Column: "created_at", Order: "desc"}},
+				Cursor:  &entity.PageCursor{Values: []string{"2024-05-01 10:00:00", "id-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid column",
+			req:     entity.GetListFilter{OrderBy: []entity.OrderBy{{Column: "id; drop table users", Order: "asc"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid direction",
+			req:     entity.GetListFilter{OrderBy: []entity.OrderBy{{Column: "id", Order: "up"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qeury, _, page, err := prepareKeysetQuery(squirrel.Select("id").From("users"), tt.req, "id", tt.expressions)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("prepareKeysetQuery() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("prepareKeysetQuery() error = %v", err)
+			}
+
+			sql, args, err := qeury.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q\nwant  %q", sql, tt.wantSQL)
+			}
+			if len(args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(args, tt.wantArgs) {
+					t.Errorf("args = %v, want %v", args, tt.wantArgs)
+				}
+			}
+			if len(page.keys) != len(tt.req.OrderBy)+1 {
+				t.Errorf("keys = %d, want %d", len(page.keys), len(tt.req.OrderBy)+1)
+			}
+		})
+	}
+}
+
+func TestKeysetAfter(t *testing.T) {
+	k := &keyset{keys: []keysetKey{
+		{expr: squirrel.Expr("a")},
+		{expr: squirrel.Expr("b"), desc: true},
+	}}
+
+	tests := []struct {
+		name     string
+		values   []string
+		backward bool
+		wantSQL  string
+		wantErr  bool
+	}{
+		{
+			name:    "forward",
+			values:  []string{"1", "2"},
+			wantSQL: "((a > ?) OR (a = ? AND b < ?))",
+		},
+		{
+			name:     "backward",
+			values:   []string{"1", "2"},
+			backward: true,
+			wantSQL:  "((a < ?) OR (a = ? AND b > ?))",
+		},
+		{
+			name:    "too few values",
+			values:  []string{"1"},
+			wantErr: true,
+		},
+		{
+			name:    "too many values",
+			values:  []string{"1", "2", "3"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, err := k.after(tt.values, tt.backward)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("after() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("after() error = %v", err)
+			}
+
+			sql, args, _ := after.ToSql()
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if want := []interface{}{"1", "1", "2"}; !reflect.DeepEqual(args, want) {
+				t.Errorf("args = %v, want %v", args, want)
+			}
+		})
+	}
+}
+
+// fakeRows hands out rows of text columns to Scan
+type fakeRows struct {
+	pgx.Rows
+	row []string
+	err error
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(dest) != len(r.row) {
+		return errors.New("wrong number of columns")
+	}
+
+	for i, d := range dest {
+		*d.(*string) = r.row[i]
+	}
+	return nil
+}
+
+func TestKeysetRow(t *testing.T) {
+	k := &keyset{keys: []keysetKey{{expr: squirrel.Expr("created_at")}, {expr: squirrel.Expr("id")}}}
+
+	var name string
+	rows := &fakeRows{row: []string{"Aziz", "2024-05-01 10:00:00", "id-1"}}
+	if err := k.Row(rows).Scan(&name); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if name != "Aziz" {
+		t.Errorf("name = %q, want Aziz", name)
+	}
+
+	rows.err = errors.New("scan failed")
+	if err := k.Row(rows).Scan(&name); !errors.Is(err, rows.err) {
+		t.Errorf("Scan() error = %v, want %v", err, rows.err)
+	}
+
+	want := [][]string{{"2024-05-01 10:00:00", "id-1"}}
+	if !reflect.DeepEqual(k.values, want) {
+		t.Errorf("values = %v, want %v", k.values, want)
+	}
+}
+
+func TestFinishKeyset(t *testing.T) {
+	const order = "created_at desc"
+	orderBy := []entity.OrderBy{{Column: "created_at", Order: "desc"}}
+	cursor := &entity.PageCursor{Order: order, Values: []string{"0"}}
+	backward := &entity.PageCursor{Order: order, Values: []string{"9"}, Backward: true}
+
+	tests := []struct {
+		name      string
+		req       entity.GetListFilter
+		items     []string // the rows in the order the query read them
+		wantItems []string
+		wantNext  []string
+		wantPrev  []string
+	}{
+		{
+			name:      "first page with more",
+			req:       entity.GetListFilter{Page: 1, Limit: 2, OrderBy: orderBy},
+			items:     []string{"a", "b", "c"},
+			wantItems: []string{"a", "b"},
+			wantNext:  []string{"b"},
+		},
+		{
+			name:      "only page",
+			req:       entity.GetListFilter{Page: 1, Limit: 2, OrderBy: orderBy},
+			items:     []string{"a", "b"},
+			wantItems: []string{"a", "b"},
+		},
+		{
+			name:      "last offset page",
+			req:       entity.GetListFilter{Page: 2, Limit: 2, OrderBy: orderBy},
+			items:     []string{"c"},
+			wantItems: []string{"c"},
+			wantPrev:  []string{"c"},
+		},
+		{
+			name:      "forward cursor with more",
+			req:       entity.GetListFilter{Limit: 2, OrderBy: orderBy, Cursor: cursor},
+			items:     []string{"c", "d", "e"},
+			wantItems: []string{"c", "d"},
+			wantNext:  []string{"d"},
+			wantPrev:  []string{"c"},
+		},
+		{
+			name:      "forward cursor on the last page",
+			req:       entity.GetListFilter{Limit: 2, OrderBy: orderBy, Cursor: cursor},
+			items:     []string{"c", "d"},
+			wantItems: []string{"c", "d"},
+			wantPrev:  []string{"c"},
+		},
+		{
+			name:      "backward cursor with more",
+			req:       entity.GetListFilter{Limit: 2, OrderBy: orderBy, Cursor: backward},
+			items:     []string{"e", "d", "c"},
+			wantItems: []string{"d", "e"},
+			wantNext:  []string{"e"},
+			wantPrev:  []string{"d"},
+		},
+		{
+			name:      "backward cursor to the first page",
+			req:       entity.GetListFilter{Limit: 2, OrderBy: orderBy, Cursor: backward},
+			items:     []string{"b", "a"},
+			wantItems: []string{"a", "b"},
+			wantNext:  []string{"b"},
+		},
+		{
+			name: "empty page",
+			req:  entity.GetListFilter{Limit: 2, OrderBy: orderBy, Cursor: cursor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &keyset{req: tt.req}
+			for _, item := range tt.items {
+				k.values = append(k.values, []string{item})
+			}
+
+			items, page := finishKeyset(k, tt.items)
+			if len(items) != 0 || len(tt.wantItems) != 0 {
+				if !reflect.DeepEqual(items, tt.wantItems) {
+					t.Errorf("items = %v, want %v", items, tt.wantItems)
+				}
+			}
+
+			checkPageCursor(t, "next", page.Next, tt.wantNext, false)
+			checkPageCursor(t, "prev", page.Prev, tt.wantPrev, true)
+		})
+	}
+}
+
+func checkPageCursor(t *testing.T, name string, got *entity.PageCursor, want []string, backward bool) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Errorf("%s = %+v, want none", name, *got)
+		}
+		return
+	}
+
+	wantCursor := entity.PageCursor{Order: "created_at desc", Values: want, Backward: backward}
+	if got == nil || !reflect.DeepEqual(*got, wantCursor) {
+		t.Errorf("%s = %+v, want %+v", name, got, wantCursor)
+	}
+}
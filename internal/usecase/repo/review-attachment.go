@@ -32,8 +32,8 @@ func (r *ReviewAttachmentRepo) Create(ctx context.Context, req entity.ReviewAtta
 	req.Id = uuid.NewString()
 
 	qeury, args, err := r.pg.Builder.Insert("review_attachments").
-		Columns(`id, review_id, filepath, content_type`).
-		Values(req.Id, req.ReviewId, req.FilePath, req.ContentType).ToSql()
+		Columns(`id, review_id, filepath, content_type, variants`).
+		Values(req.Id, req.ReviewId, req.FilePath, req.ContentType, variantsToJSON(req.Variants)).ToSql()
 	if err != nil {
 		return entity.ReviewAttachment{}, err
 	}
@@ -46,6 +46,8 @@ func (r *ReviewAttachmentRepo) Create(ctx context.Context, req entity.ReviewAtta
 	return req, nil
 }
 
+// MultipleUpsert keeps the listed attachments of a review and deletes the others. Attachments without an id are
+// inserted as they are, without variants, so handlers only list attachments made by an upload.
 func (r *ReviewAttachmentRepo) MultipleUpsert(ctx context.Context, req entity.ReviewAttachmentMultipleInsertRequest) ([]entity.ReviewAttachment, error) {
 	hasNewAttachment := false
 
@@ -157,10 +159,11 @@ func (r *ReviewAttachmentRepo) GetSingle(ctx context.Context, req entity.Id) (en
 	response := entity.ReviewAttachment{}
 	var (
 		createdAt, updatedAt time.Time
+		variants             []byte
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, review_id, filepath, content_type, created_at, updated_at`).
+		Select(`id, review_id, filepath, content_type, variants, created_at, updated_at`).
 		From("review_attachments")
 
 	switch {
@@ -176,7 +179,12 @@ func (r *ReviewAttachmentRepo) GetSingle(ctx context.Context, req entity.Id) (en
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
-		Scan(&response.Id, &response.ReviewId, &response.FilePath, &response.ContentType, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.ReviewId, &response.FilePath, &response.ContentType, &variants, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.ReviewAttachment{}, err
+	}
+
+	response.Variants, err = variantsFromJSON(variants)
 	if err != nil {
 		return entity.ReviewAttachment{}, err
 	}
@@ -191,10 +199,11 @@ func (r *ReviewAttachmentRepo) GetList(ctx context.Context, req entity.GetListFi
 	var (
 		response             = entity.ReviewAttachmentList{}
 		createdAt, updatedAt time.Time
+		variants             []byte
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, review_id, filepath, content_type, created_at, updated_at`).
+		Select(`id, review_id, filepath, content_type, variants, created_at, updated_at`).
 		From("review_attachments")
 
 	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
@@ -212,7 +221,12 @@ func (r *ReviewAttachmentRepo) GetList(ctx context.Context, req entity.GetListFi
 
 	for rows.Next() {
 		var item entity.ReviewAttachment
-		err = rows.Scan(&item.Id, &item.ReviewId, &item.FilePath, &item.ContentType, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.ReviewId, &item.FilePath, &item.ContentType, &variants, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.Variants, err = variantsFromJSON(variants)
 		if err != nil {
 			return response, err
 		}
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+type ReviewReplyRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewReviewReplyRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewReplyRepo {
+	return &ReviewReplyRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewReplyRepo) Create(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error) {
+	req.ID = uuid.NewString()
+
+	qeury, args, err := r.pg.Builder.Insert("review_replies").
+		Columns(`id, review_id, user_id, comment`).
+		Values(req.ID, req.ReviewID, req.UserID, req.Comment).ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	return r.GetSingle(ctx, entity.ReviewReplySingleRequest{ID: req.ID})
+}
+
+func (r *ReviewReplyRepo) GetSingle(ctx context.Context, req entity.ReviewReplySingleRequest) (entity.ReviewReply, error) {
+	response := entity.ReviewReply{}
+	var (
+		createdAt, updatedAt time.Time
+		userID               sql.NullString
+	)
+
+	qeuryBuilder := r.pg.Builder.
+		Select(`id, review_id, user_id, comment, created_at, updated_at`).
+		From("review_replies")
+
+	switch {
+	case req.ID != "":
+		qeuryBuilder = qeuryBuilder.Where("id = ?", req.ID)
+	case req.ReviewID != "":
+		qeuryBuilder = qeuryBuilder.Where("review_id = ?", req.ReviewID)
+	default:
+		return entity.ReviewReply{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
+		Scan(&response.ID, &response.ReviewID, &userID, &response.Comment, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	response.UserID = userID.String
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *ReviewReplyRepo) Update(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error) {
+	mp := map[string]interface{}{
+		"comment":    req.Comment,
+		"updated_at": "now()",
+	}
+
+	qeury, args, err := r.pg.Builder.Update("review_replies").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	return r.GetSingle(ctx, entity.ReviewReplySingleRequest{ID: req.ID})
+}
+
+func (r *ReviewReplyRepo) Delete(ctx context.Context, req entity.Id) error {
+	qeury, args, err := r.pg.Builder.Delete("review_replies").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
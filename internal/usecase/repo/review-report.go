@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+type ReviewReportRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewReviewReportRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewReportRepo {
+	return &ReviewReportRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewReportRepo) Create(ctx context.Context, req entity.ReviewReport) (entity.ReviewReport, error) {
+	var createdAt, updatedAt time.Time
+
+	req.ID = uuid.NewString()
+
+	qeury, args, err := r.pg.Builder.Insert("review_reports").
+		Columns(`id, review_id, user_id, reason`).
+		Values(req.ID, req.ReviewID, req.UserID, req.Reason).
+		Suffix("RETURNING created_at, updated_at").ToSql()
+	if err != nil {
+		return entity.ReviewReport{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, qeury, args...).Scan(&createdAt, &updatedAt)
+	if err != nil {
+		return entity.ReviewReport{}, err
+	}
+
+	req.CreatedAt = createdAt.Format(time.RFC3339)
+	req.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return req, nil
+}
+
+func (r *ReviewReportRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewReportList, error) {
+	var (
+		response             = entity.ReviewReportList{}
+		createdAt, updatedAt time.Time
+	)
+
+	qeuryBuilder := r.pg.Builder.
+		Select(`id, review_id, user_id, reason, resolved, created_at, updated_at`).
+		From("review_reports")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.ReviewReport
+		err = rows.Scan(&item.ID, &item.ReviewID, &item.UserID, &item.Reason, &item.Resolved, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("review_reports").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *ReviewReportRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	qeury, args, err := r.pg.Builder.Update("review_reports").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+)
+
+type ReviewRevisionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewReviewRevisionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewRevisionRepo {
+	return &ReviewRevisionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewRevisionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewRevisionList, error) {
+	var (
+		response              = entity.ReviewRevisionList{}
+		createdAt, replacedAt time.Time
+		comment               sql.NullString
+	)
+
+	qeuryBuilder := r.pg.Builder.
+		Select(`id, review_id, rating, comment, created_at, replaced_at`).
+		From("review_revisions")
+
+	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.ReviewRevision
+		err = rows.Scan(&item.ID, &item.ReviewID, &item.Rating, &comment, &createdAt, &replacedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.ReplacedAt = replacedAt.Format(time.RFC3339)
+		item.Comment = comment.String
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("review_revisions").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
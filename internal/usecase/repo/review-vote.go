@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+)
+
+type ReviewVoteRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewReviewVoteRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewVoteRepo {
+	return &ReviewVoteRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewVoteRepo) Create(ctx context.Context, req entity.ReviewVote) (entity.ReviewVote, error) {
+	qeury, args, err := r.pg.Builder.Insert("review_votes").
+		Columns(`review_id, user_id, type`).
+		Values(req.ReviewID, req.UserID, req.Type).ToSql()
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	return r.GetSingle(ctx, entity.ReviewVoteSingleRequest{ReviewID: req.ReviewID, UserID: req.UserID})
+}
+
+func (r *ReviewVoteRepo) GetSingle(ctx context.Context, req entity.ReviewVoteSingleRequest) (entity.ReviewVote, error) {
+	response := entity.ReviewVote{}
+	var (
+		createdAt, updatedAt time.Time
+	)
+
+	qeury, args, err := r.pg.Builder.
+		Select(`review_id, user_id, type, created_at, updated_at`).
+		From("review_votes").
+		Where(squirrel.Eq{"review_id": req.ReviewID, "user_id": req.UserID}).ToSql()
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
+		Scan(&response.ReviewID, &response.UserID, &response.Type, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *ReviewVoteRepo) Update(ctx context.Context, req entity.ReviewVote) (entity.ReviewVote, error) {
+	mp := map[string]interface{}{
+		"type":       req.Type,
+		"updated_at": "now()",
+	}
+
+	qeury, args, err := r.pg.Builder.Update("review_votes").SetMap(mp).
+		Where(squirrel.Eq{"review_id": req.ReviewID, "user_id": req.UserID}).ToSql()
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return entity.ReviewVote{}, err
+	}
+
+	return r.GetSingle(ctx, entity.ReviewVoteSingleRequest{ReviewID: req.ReviewID, UserID: req.UserID})
+}
+
+func (r *ReviewVoteRepo) Delete(ctx context.Context, req entity.ReviewVoteSingleRequest) error {
+	qeury, args, err := r.pg.Builder.Delete("review_votes").
+		Where(squirrel.Eq{"review_id": req.ReviewID, "user_id": req.UserID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewVoteRepo) GetUserVotes(ctx context.Context, req entity.ReviewUserVotesRequest) (map[string]string, error) {
+	response := map[string]string{}
+
+	if req.UserID == "" || len(req.ReviewIDs) == 0 {
+		return response, nil
+	}
+
+	qeury, args, err := r.pg.Builder.
+		Select(`review_id, type`).
+		From("review_votes").
+		Where(squirrel.Eq{"user_id": req.UserID, "review_id": req.ReviewIDs}).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewID, voteType string
+		err = rows.Scan(&reviewID, &voteType)
+		if err != nil {
+			return response, err
+		}
+
+		response[reviewID] = voteType
+	}
+
+	return response, rows.Err()
+}
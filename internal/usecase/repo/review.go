@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/abdulazizax/yelp/config"
 	"github.com/abdulazizax/yelp/internal/entity"
 	"github.com/abdulazizax/yelp/pkg/logger"
 	"github.com/abdulazizax/yelp/pkg/postgres"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 type ReviewRepo struct {
@@ -31,9 +33,13 @@ func NewReviewRepo(pg *postgres.Postgres, config *config.Config, logger *logger.
 func (r *ReviewRepo) Create(ctx context.Context, req entity.Review) (entity.Review, error) {
 	req.ID = uuid.NewString()
 
+	if req.Status == "" {
+		req.Status = config.ReviewStatusPublished
+	}
+
 	qeury, args, err := r.pg.Builder.Insert("reviews").
-		Columns(`id, business_id, user_id, rating, comment`).
-		Values(req.ID, req.BusinessID, req.UserID, req.Rating, req.Comment).ToSql()
+		Columns(`id, business_id, user_id, rating, comment, status, moderation_reason`).
+		Values(req.ID, req.BusinessID, req.UserID, req.Rating, req.Comment, req.Status, nullString(req.ModerationReason)).ToSql()
 	if err != nil {
 		return entity.Review{}, err
 	}
@@ -46,20 +52,17 @@ func (r *ReviewRepo) Create(ctx context.Context, req entity.Review) (entity.Revi
 	return req, nil
 }
 
-func (r *ReviewRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Review, error) {
-	response := entity.Review{}
-	var (
-		createdAt, updatedAt time.Time
-		comment              sql.NullString
-	)
-
+func (r *ReviewRepo) GetSingle(ctx context.Context, req entity.ReviewSingleRequest) (entity.Review, error) {
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, business_id, user_id, rating, comment, created_at, updated_at`).
-		From("reviews")
+		Select(reviewColumns).
+		From("reviews").
+		LeftJoin(reviewReplyJoin)
 
 	switch {
 	case req.ID != "":
-		qeuryBuilder = qeuryBuilder.Where("id = ?", req.ID)
+		qeuryBuilder = qeuryBuilder.Where("reviews.id = ?", req.ID)
+	case req.BusinessID != "" && req.UserID != "":
+		qeuryBuilder = qeuryBuilder.Where("reviews.business_id = ? AND reviews.user_id = ?", req.BusinessID, req.UserID)
 	default:
 		return entity.Review{}, fmt.Errorf("GetSingle - invalid request")
 	}
@@ -69,34 +72,32 @@ func (r *ReviewRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Revie
 		return entity.Review{}, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
-		Scan(&response.ID, &response.BusinessID, &response.UserID, &response.Rating,
-			&comment, &createdAt, &updatedAt)
-	if err != nil {
-		return entity.Review{}, err
-	}
-
-	response.CreatedAt = createdAt.Format(time.RFC3339)
-	response.UpdatedAt = updatedAt.Format(time.RFC3339)
-	if comment.Valid {
-		response.Comment = comment.String
-	}
-
-	return response, nil
+	return scanReview(r.pg.Pool.QueryRow(ctx, qeury, args...))
 }
 
-func (r *ReviewRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewList, error) {
-	var (
-		response             = entity.ReviewList{}
-		createdAt, updatedAt time.Time
-		comment              sql.NullString
-	)
+func (r *ReviewRepo) GetList(ctx context.Context, req entity.ReviewListFilter) (entity.ReviewList, error) {
+	response := entity.ReviewList{}
+
+	// reviews held for moderation, hidden or removed are only listed to their author
+	visible := squirrel.And{}
+	if !req.WithHidden {
+		published := squirrel.Or{squirrel.Eq{"reviews.status": config.ReviewStatusPublished}}
+		if req.ViewerID != "" {
+			published = append(published, squirrel.Eq{"reviews.user_id": req.ViewerID})
+		}
+		visible = append(visible, published)
+	}
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, business_id, user_id, rating, comment, created_at, updated_at`).
-		From("reviews")
+		Select(reviewColumns).
+		From("reviews").
+		LeftJoin(reviewReplyJoin).
+		Where(visible)
 
-	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+	qeuryBuilder, where, page, err := prepareKeysetQuery(qeuryBuilder, req.GetListFilter, "reviews.id", nil)
+	if err != nil {
+		return response, err
+	}
 
 	qeury, args, err := qeuryBuilder.ToSql()
 	if err != nil {
@@ -110,28 +111,27 @@ func (r *ReviewRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 	defer rows.Close()
 
 	for rows.Next() {
-		var item entity.Review
-		err = rows.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.Rating,
-			&comment, &createdAt, &updatedAt)
+		item, err := scanReview(page.Row(rows))
 		if err != nil {
 			return response, err
 		}
 
-		item.CreatedAt = createdAt.Format(time.RFC3339)
-		item.UpdatedAt = updatedAt.Format(time.RFC3339)
-		if comment.Valid {
-			item.Comment = comment.String
-		}
-
 		response.Items = append(response.Items, item)
 	}
 
-	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("reviews").Where(where).ToSql()
+	response.Items, response.Page = finishKeyset(page, response.Items)
+
+	if req.SkipCount {
+		return response, nil
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("reviews").Where(where).Where(visible).ToSql()
 	if err != nil {
 		return response, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	response.Count = new(int)
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(response.Count)
 	if err != nil {
 		return response, err
 	}
@@ -146,6 +146,15 @@ func (r *ReviewRepo) Update(ctx context.Context, req entity.Review) (entity.Revi
 		"updated_at": "now()",
 	}
 
+	// the status only changes when screening holds the new text for moderation, which takes a published
+	// review back to pending but never brings back one a moderator hid or removed
+	if req.Status != "" {
+		mp["status"] = squirrel.Expr("CASE WHEN status IN (?, ?) THEN ? ELSE status END",
+			config.ReviewStatusPublished, config.ReviewStatusPending, req.Status)
+		mp["moderation_reason"] = squirrel.Expr("CASE WHEN status IN (?, ?) THEN ? ELSE moderation_reason END",
+			config.ReviewStatusPublished, config.ReviewStatusPending, nullString(req.ModerationReason))
+	}
+
 	qeury, args, err := r.pg.Builder.Update("reviews").SetMap(mp).Where("id = ?", req.ID).ToSql()
 	if err != nil {
 		return entity.Review{}, err
@@ -159,6 +168,39 @@ func (r *ReviewRepo) Update(ctx context.Context, req entity.Review) (entity.Revi
 	return req, nil
 }
 
+// GetRecent lists the reviews a user wrote within the window before now, measured by the database clock
+func (r *ReviewRepo) GetRecent(ctx context.Context, req entity.RecentReviewsRequest) ([]entity.Review, error) {
+	var response []entity.Review
+
+	qeury, args, err := r.pg.Builder.
+		Select(reviewColumns).
+		From("reviews").
+		LeftJoin(reviewReplyJoin).
+		Where("reviews.user_id = ? AND reviews.created_at > now() - ?::interval", req.UserID, req.Window).
+		OrderBy("reviews.created_at DESC").
+		Limit(uint64(req.Limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
 func (r *ReviewRepo) Delete(ctx context.Context, req entity.Id) error {
 	qeury, args, err := r.pg.Builder.Delete("reviews").Where("id = ?", req.ID).ToSql()
 	if err != nil {
@@ -172,3 +214,71 @@ func (r *ReviewRepo) Delete(ctx context.Context, req entity.Id) error {
 
 	return nil
 }
+
+func (r *ReviewRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	qeury, args, err := r.pg.Builder.Update("reviews").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// reviewColumns selects a review together with the owner's reply, filters therefore qualify review columns as reviews.<column>
+const (
+	reviewColumns = `reviews.id, reviews.business_id, reviews.user_id, reviews.rating, reviews.comment, reviews.created_at, reviews.updated_at,
+		reviews.useful_count, reviews.funny_count, reviews.cool_count, reviews.status, reviews.report_count, reviews.moderation_reason,
+		rr.id, rr.user_id, rr.comment, rr.created_at, rr.updated_at`
+	reviewReplyJoin = "review_replies rr ON rr.review_id = reviews.id"
+)
+
+func scanReview(row pgx.Row) (entity.Review, error) {
+	var (
+		item                           entity.Review
+		createdAt, updatedAt           time.Time
+		comment, moderationReason      sql.NullString
+		replyID, replyUserID, replyMsg sql.NullString
+		replyCreatedAt, replyUpdatedAt sql.NullTime
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.Rating, &comment, &createdAt, &updatedAt,
+		&item.Votes.Useful, &item.Votes.Funny, &item.Votes.Cool, &item.Status, &item.ReportCount, &moderationReason,
+		&replyID, &replyUserID, &replyMsg, &replyCreatedAt, &replyUpdatedAt)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if comment.Valid {
+		item.Comment = comment.String
+	}
+	item.ModerationReason = moderationReason.String
+
+	if replyID.Valid {
+		item.Reply = &entity.ReviewReply{
+			ID:        replyID.String,
+			ReviewID:  item.ID,
+			UserID:    replyUserID.String,
+			Comment:   replyMsg.String,
+			CreatedAt: replyCreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt: replyUpdatedAt.Time.Format(time.RFC3339),
+		}
+	}
+
+	return item, nil
+}
@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/abdulazizax/yelp/config"
+	"github.com/abdulazizax/yelp/internal/entity"
+	"github.com/abdulazizax/yelp/pkg/etc"
+	"github.com/abdulazizax/yelp/pkg/logger"
+	"github.com/abdulazizax/yelp/pkg/postgres"
+)
+
+type SearchRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewSearchRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *SearchRepo {
+	return &SearchRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+const (
+	searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+
+	// searchQuery ranks full-text matches of businesses and published reviews together, business names and
+	// addresses also match by trigram similarity so that misspelled queries still find them. Snippets are
+	// HTML, so the user written text is escaped before ts_headline adds the marks.
+	searchQuery = `
+		WITH q AS (
+			SELECT websearch_to_tsquery('english', $1) AS query, $1::text AS term
+		), results AS (
+			SELECT $2::text AS type, b.id, b.id AS business_id, b.name AS title,
+				ts_headline('english', html_escape(concat_ws(' - ', b.name, b.description, b.address)), q.query, $6) AS snippet,
+				ts_rank_cd(b.search_vector, q.query) + GREATEST(similarity(b.name, q.term), similarity(b.address, q.term)) AS rank
+			FROM businesses b, q
+			WHERE $4 AND (b.search_vector @@ q.query OR b.name % q.term OR b.address % q.term)
+			UNION ALL
+			SELECT $3::text, r.id, r.business_id, b.name,
+				ts_headline('english', html_escape(r.comment), q.query, $6),
+				ts_rank_cd(r.search_vector, q.query)
+			FROM reviews r JOIN businesses b ON b.id = r.business_id, q
+			WHERE $5 AND r.status = $7 AND r.search_vector @@ q.query
+		)
+		SELECT type, id, business_id, title, COALESCE(snippet, ''), rank::float8, COUNT(1) OVER ()
+		FROM results
+		ORDER BY rank DESC, id
+		LIMIT $8 OFFSET $9`
+)
+
+// Search returns one page of ranked results, Count holds the matches of every page
+func (r *SearchRepo) Search(ctx context.Context, req entity.SearchRequest) (entity.SearchResultList, error) {
+	response := entity.SearchResultList{}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, searchQuery, req.Query, config.SearchTypeBusiness, config.SearchTypeReview,
+		req.Businesses, req.Reviews, searchHeadlineOptions, config.ReviewStatusPublished, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.SearchResult
+		err = rows.Scan(&item.Type, &item.ID, &item.BusinessID, &item.Title, &item.Snippet, &item.Rank, &response.Count)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	return response, rows.Err()
+}
+
+// suggestScore ranks names by a prefix match first, which outranks any similarity as that is at most 1
+const suggestScore = "(name ILIKE ?)::int + similarity(name, ?)"
+
+// Suggest completes the typed text with business names, category names and popular searches, in that order
+func (r *SearchRepo) Suggest(ctx context.Context, req entity.SuggestRequest) (entity.SuggestionList, error) {
+	var (
+		response       = entity.SuggestionList{Items: []entity.Suggestion{}}
+		prefix         = likeEscape(req.Query) + "%"
+		distanceColumn = squirrel.Expr("NULL::float8")
+	)
+
+	if req.Geo != nil {
+		distanceColumn = squirrel.Expr("earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude))", req.Geo.Latitude, req.Geo.Longitude)
+	}
+
+	qeuryBuilder := r.pg.Builder.
+		Select("id::text, name").
+		Column(distanceColumn).
+		From("businesses").
+		Where(squirrel.Or{squirrel.ILike{"name": prefix}, squirrel.Expr("name % ?", req.Query)}).
+		Limit(uint64(req.Limit))
+
+	if req.Geo != nil {
+		// within the same match quality the closest businesses come first, a business 10 km away loses half of its bonus
+		qeuryBuilder = qeuryBuilder.OrderByClause(suggestScore+` + COALESCE(1 / (1 + earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude)) / 10000), 0) DESC`,
+			prefix, req.Query, req.Geo.Latitude, req.Geo.Longitude)
+	} else {
+		qeuryBuilder = qeuryBuilder.OrderByClause(suggestScore+" DESC", prefix, req.Query)
+	}
+
+	err := r.collectSuggestions(ctx, qeuryBuilder, config.SuggestionTypeBusiness, &response)
+	if err != nil {
+		return response, err
+	}
+
+	qeuryBuilder = r.pg.Builder.
+		Select("id::text, name, NULL::float8").
+		From("business_categories").
+		Where(squirrel.Or{squirrel.ILike{"name": prefix}, squirrel.Expr("name % ?", req.Query)}).
+		OrderByClause(suggestScore+" DESC", prefix, req.Query).
+		Limit(uint64(req.Limit))
+
+	err = r.collectSuggestions(ctx, qeuryBuilder, config.SuggestionTypeCategory, &response)
+	if err != nil {
+		return response, err
+	}
+
+	// a search only a few users ran is theirs, it is neither suggested nor can it be planted by one user
+	qeuryBuilder = r.pg.Builder.
+		Select("'', query, NULL::float8").
+		From("search_queries").
+		Where(squirrel.Like{"query": likeEscape(etc.NormalizeQuery(req.Query)) + "%"}).
+		Where("user_count >= ?", config.SuggestMinUsers).
+		OrderBy("user_count DESC", "hits DESC", "query").
+		Limit(uint64(req.Limit))
+
+	err = r.collectSuggestions(ctx, qeuryBuilder, config.SuggestionTypeQuery, &response)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// RecordQuery counts a search that found something so that it can be suggested later, user_count only
+// grows with the first search of every user
+func (r *SearchRepo) RecordQuery(ctx context.Context, req entity.SearchQueryRecord) error {
+	query := etc.NormalizeQuery(req.Query)
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qeury, args, err := r.pg.Builder.Insert("search_query_users").
+		Columns("query, user_id").
+		Values(query, req.UserID).
+		Suffix("ON CONFLICT (query, user_id) DO UPDATE SET last_searched_at = now() RETURNING (xmax = 0)").ToSql()
+	if err != nil {
+		return err
+	}
+
+	var firstSearch bool
+	err = tx.QueryRow(ctx, qeury, args...).Scan(&firstSearch)
+	if err != nil {
+		return err
+	}
+
+	newUser := 0
+	if firstSearch {
+		newUser = 1
+	}
+
+	qeury, args, err = r.pg.Builder.Insert("search_queries").
+		Columns("query, user_count").
+		Values(query, newUser).
+		Suffix(`ON CONFLICT (query) DO UPDATE SET hits = search_queries.hits + 1,
+			user_count = search_queries.user_count + EXCLUDED.user_count, last_searched_at = now()`).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *SearchRepo) collectSuggestions(ctx context.Context, qeuryBuilder squirrel.SelectBuilder, suggestionType string, response *entity.SuggestionList) error {
+	qeury, args, err := qeuryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, qeury, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item     = entity.Suggestion{Type: suggestionType}
+			distance *float64
+		)
+
+		err = rows.Scan(&item.ID, &item.Text, &distance)
+		if err != nil {
+			return err
+		}
+
+		item.DistanceM = distance
+		response.Items = append(response.Items, item)
+	}
+
+	return rows.Err()
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/abdulazizax/yelp/config"
 	"github.com/abdulazizax/yelp/internal/entity"
 	"github.com/abdulazizax/yelp/pkg/logger"
@@ -37,8 +38,8 @@ func (r *SessionRepo) Create(ctx context.Context, req entity.Session) (entity.Se
 	}
 
 	qeury, args, err := r.pg.Builder.Insert("session").
-		Columns(`id, user_id, ip_address, user_agent, is_active, expires_at, platform`).
-		Values(req.ID, req.UserID, req.IPAddress, req.UserAgent, req.IsActive, expireDate, req.Platform).ToSql()
+		Columns(`id, user_id, ip_address, user_agent, is_active, expires_at, platform, refresh_token_hash`).
+		Values(req.ID, req.UserID, req.IPAddress, req.UserAgent, req.IsActive, expireDate, req.Platform, req.RefreshTokenHash).ToSql()
 	if err != nil {
 		return entity.Session{}, err
 	}
@@ -56,10 +57,11 @@ func (r *SessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Sess
 	var (
 		createdAt, updatedAt    time.Time
 		expiresAt, lastActiveAt sql.NullTime
+		refreshTokenHash        sql.NullString
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, created_at, updated_at`).
+		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, refresh_token_hash, created_at, updated_at`).
 		From("session").Where("id = ?", req.ID)
 
 	qeury, args, err := qeuryBuilder.ToSql()
@@ -69,7 +71,7 @@ func (r *SessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Sess
 
 	err = r.pg.Pool.QueryRow(ctx, qeury, args...).
 		Scan(&response.ID, &response.UserID, &response.IPAddress, &response.UserAgent,
-			&response.IsActive, &expiresAt, &lastActiveAt, &response.Platform, &createdAt, &updatedAt)
+			&response.IsActive, &expiresAt, &lastActiveAt, &response.Platform, &refreshTokenHash, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Session{}, err
 	}
@@ -84,6 +86,10 @@ func (r *SessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Sess
 		response.LastActiveAt = lastActiveAt.Time.Format(time.RFC3339)
 	}
 
+	if refreshTokenHash.Valid {
+		response.RefreshTokenHash = refreshTokenHash.String
+	}
+
 	return response, nil
 }
 
@@ -93,10 +99,14 @@ func (r *SessionRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 	)
 
 	qeuryBuilder := r.pg.Builder.
-		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, created_at, updated_at`).
+		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, refresh_token_hash, created_at, updated_at`).
 		From("session")
 
-	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+	qeuryBuilder, where, page, err := prepareKeysetQuery(qeuryBuilder, req, "id", nil)
+	if err != nil {
+		return response, err
+	}
+
 	qeury, args, err := qeuryBuilder.ToSql()
 	if err != nil {
 		return response, err
@@ -112,10 +122,11 @@ func (r *SessionRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 		var (
 			createdAt, updatedAt    time.Time
 			expiresAt, lastActiveAt sql.NullTime
+			refreshTokenHash        sql.NullString
 			item                    entity.Session
 		)
-		err = rows.Scan(&item.ID, &item.UserID, &item.IPAddress, &item.UserAgent,
-			&item.IsActive, &expiresAt, &lastActiveAt, &item.Platform, &createdAt, &updatedAt)
+		err = page.Row(rows).Scan(&item.ID, &item.UserID, &item.IPAddress, &item.UserAgent,
+			&item.IsActive, &expiresAt, &lastActiveAt, &item.Platform, &refreshTokenHash, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -130,15 +141,26 @@ func (r *SessionRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 			item.LastActiveAt = lastActiveAt.Time.Format(time.RFC3339)
 		}
 
+		if refreshTokenHash.Valid {
+			item.RefreshTokenHash = refreshTokenHash.String
+		}
+
 		response.Items = append(response.Items, item)
 	}
 
+	response.Items, response.Page = finishKeyset(page, response.Items)
+
+	if req.SkipCount {
+		return response, nil
+	}
+
 	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("session").Where(where).ToSql()
 	if err != nil {
 		return response, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	response.Count = new(int)
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(response.Count)
 	if err != nil {
 		return response, err
 	}
@@ -203,3 +225,68 @@ func (r *SessionRepo) UpdateField(ctx context.Context, req entity.UpdateFieldReq
 
 	return response, nil
 }
+
+// RotateRefreshToken replaces the refresh token of a session and remembers the old one, so presenting it again
+// can be told apart from a wrong token. Nothing changes when the old hash is no longer current.
+func (r *SessionRepo) RotateRefreshToken(ctx context.Context, req entity.RefreshTokenRotation) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return response, err
+	}
+	defer tx.Rollback(ctx)
+
+	qeury, args, err := r.pg.Builder.Update("session").
+		Set("refresh_token_hash", req.NewHash).
+		Set("expires_at", req.ExpiresAt).
+		Set("last_active_at", squirrel.Expr("now()")).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where("id = ? AND refresh_token_hash = ?", req.SessionID, req.OldHash).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+	if response.RowsEffected == 0 {
+		return response, nil
+	}
+
+	qeury, args, err = r.pg.Builder.Insert("session_rotated_tokens").
+		Columns("token_hash, session_id").
+		Values(req.OldHash, req.SessionID).
+		Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	_, err = tx.Exec(ctx, qeury, args...)
+	if err != nil {
+		return response, err
+	}
+
+	return response, tx.Commit(ctx)
+}
+
+// IsRotatedRefreshToken reports whether tokenHash is a refresh token the session already rotated away from
+func (r *SessionRepo) IsRotatedRefreshToken(ctx context.Context, sessionID, tokenHash string) (bool, error) {
+	var rotated bool
+
+	qeury, args, err := r.pg.Builder.
+		Select("EXISTS (SELECT 1 FROM session_rotated_tokens WHERE session_id = ? AND token_hash = ?)", sessionID, tokenHash).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, qeury, args...).Scan(&rotated)
+	if err != nil {
+		return false, err
+	}
+
+	return rotated, nil
+}
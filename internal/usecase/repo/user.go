@@ -103,7 +103,10 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 		Select(`id, user_type, user_role, full_name, username, email, password, bio, gender, profile_picture, status, created_at, updated_at`).
 		From("users")
 
-	qeuryBuilder, where := PrepareGetListQuery(qeuryBuilder, req)
+	qeuryBuilder, where, page, err := prepareKeysetQuery(qeuryBuilder, req, "id", nil)
+	if err != nil {
+		return response, err
+	}
 
 	qeury, args, err := qeuryBuilder.ToSql()
 	if err != nil {
@@ -118,7 +121,7 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 
 	for rows.Next() {
 		var item entity.User
-		err = rows.Scan(&item.ID, &item.UserType, &item.UserRole, &item.FullName, &item.Username,
+		err = page.Row(rows).Scan(&item.ID, &item.UserType, &item.UserRole, &item.FullName, &item.Username,
 			&item.Email, &item.Password, &bio, &item.Gender, &profile_picture, &item.Status, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
@@ -136,12 +139,19 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 		response.Items = append(response.Items, item)
 	}
 
+	response.Items, response.Page = finishKeyset(page, response.Items)
+
+	if req.SkipCount {
+		return response, nil
+	}
+
 	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("users").Where(where).ToSql()
 	if err != nil {
 		return response, err
 	}
 
-	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	response.Count = new(int)
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(response.Count)
 	if err != nil {
 		return response, err
 	}
@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+CREATE INDEX IF NOT EXISTS businesses_location_idx ON businesses USING gist (ll_to_earth(latitude, longitude));
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS reviews_business_rating ON reviews;
+DROP FUNCTION IF EXISTS reviews_refresh_business_rating();
+DROP FUNCTION IF EXISTS refresh_business_rating(UUID);
+
+DROP INDEX IF EXISTS businesses_review_count_idx;
+DROP INDEX IF EXISTS businesses_avg_rating_idx;
+
+ALTER TABLE businesses
+    DROP COLUMN IF EXISTS rating_histogram,
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS avg_rating;
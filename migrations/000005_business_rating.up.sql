@@ -0,0 +1,53 @@
+ALTER TABLE businesses
+    ADD COLUMN IF NOT EXISTS avg_rating NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_histogram INT[] NOT NULL DEFAULT '{0,0,0,0,0}';
+
+CREATE INDEX IF NOT EXISTS businesses_avg_rating_idx ON businesses (avg_rating);
+CREATE INDEX IF NOT EXISTS businesses_review_count_idx ON businesses (review_count);
+
+-- Recomputes the rating statistics of a single business from its reviews
+CREATE OR REPLACE FUNCTION refresh_business_rating(p_business_id UUID) RETURNS void AS $$
+BEGIN
+    UPDATE businesses b SET
+        avg_rating = s.avg_rating,
+        review_count = s.review_count,
+        rating_histogram = s.rating_histogram
+    FROM (
+        SELECT
+            COALESCE(ROUND(AVG(rating), 2), 0) AS avg_rating,
+            COUNT(1) AS review_count,
+            ARRAY[
+                COUNT(1) FILTER (WHERE rating = 1),
+                COUNT(1) FILTER (WHERE rating = 2),
+                COUNT(1) FILTER (WHERE rating = 3),
+                COUNT(1) FILTER (WHERE rating = 4),
+                COUNT(1) FILTER (WHERE rating = 5)
+            ]::INT[] AS rating_histogram
+        FROM reviews
+        WHERE business_id = p_business_id
+    ) s
+    WHERE b.id = p_business_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION reviews_refresh_business_rating() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM refresh_business_rating(NEW.business_id);
+    END IF;
+
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.business_id IS DISTINCT FROM NEW.business_id) THEN
+        PERFORM refresh_business_rating(OLD.business_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_business_rating
+    AFTER INSERT OR UPDATE OF business_id, rating OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION reviews_refresh_business_rating();
+
+-- Backfill businesses that already have reviews
+SELECT refresh_business_rating(id) FROM businesses;
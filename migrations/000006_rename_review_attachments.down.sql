@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS review_attachments RENAME TO reviews_attachments;
@@ -0,0 +1,2 @@
+-- ReviewAttachmentRepo reads and writes review_attachments
+ALTER TABLE IF EXISTS reviews_attachments RENAME TO review_attachments;
@@ -0,0 +1,2 @@
+ALTER TABLE review_attachments DROP COLUMN IF EXISTS variants;
+ALTER TABLE business_attachments DROP COLUMN IF EXISTS variants;
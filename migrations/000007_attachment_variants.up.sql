@@ -0,0 +1,2 @@
+ALTER TABLE business_attachments ADD COLUMN IF NOT EXISTS variants JSONB;
+ALTER TABLE review_attachments ADD COLUMN IF NOT EXISTS variants JSONB;
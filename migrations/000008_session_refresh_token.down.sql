@@ -0,0 +1 @@
+ALTER TABLE session DROP COLUMN IF EXISTS refresh_token_hash;
@@ -0,0 +1,4 @@
+ALTER TABLE session ADD COLUMN IF NOT EXISTS refresh_token_hash VARCHAR(64);
+
+-- sessions issued before refresh tokens existed never expired
+UPDATE session SET expires_at = LEAST(expires_at, now() + INTERVAL '7 days');
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS review_votes_counts ON review_votes;
+DROP FUNCTION IF EXISTS review_votes_refresh_counts();
+DROP FUNCTION IF EXISTS refresh_review_votes(UUID);
+
+DROP INDEX IF EXISTS reviews_helpfulness_idx;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS cool_count,
+    DROP COLUMN IF EXISTS funny_count,
+    DROP COLUMN IF EXISTS useful_count;
+
+DROP TABLE IF EXISTS review_votes;
+DROP TYPE IF EXISTS review_vote_type;
@@ -0,0 +1,57 @@
+CREATE TYPE review_vote_type AS ENUM ('useful', 'funny', 'cool');
+
+-- A user has at most one vote per review, changing the type replaces it
+CREATE TABLE IF NOT EXISTS review_votes (
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type review_vote_type NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS review_votes_user_id_idx ON review_votes (user_id);
+
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS useful_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS funny_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cool_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS reviews_helpfulness_idx ON reviews (useful_count DESC, created_at DESC);
+
+-- Recomputes the vote counters of a single review from its votes
+CREATE OR REPLACE FUNCTION refresh_review_votes(p_review_id UUID) RETURNS void AS $$
+BEGIN
+    UPDATE reviews r SET
+        useful_count = s.useful_count,
+        funny_count = s.funny_count,
+        cool_count = s.cool_count
+    FROM (
+        SELECT
+            COUNT(1) FILTER (WHERE type = 'useful') AS useful_count,
+            COUNT(1) FILTER (WHERE type = 'funny') AS funny_count,
+            COUNT(1) FILTER (WHERE type = 'cool') AS cool_count
+        FROM review_votes
+        WHERE review_id = p_review_id
+    ) s
+    WHERE r.id = p_review_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION review_votes_refresh_counts() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM refresh_review_votes(NEW.review_id);
+    END IF;
+
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.review_id IS DISTINCT FROM NEW.review_id) THEN
+        PERFORM refresh_review_votes(OLD.review_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER review_votes_counts
+    AFTER INSERT OR UPDATE OF review_id, type OR DELETE ON review_votes
+    FOR EACH ROW EXECUTE FUNCTION review_votes_refresh_counts();
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS reviews_revision ON reviews;
+DROP FUNCTION IF EXISTS reviews_save_revision();
+
+DROP INDEX IF EXISTS reviews_business_id_user_id_key;
+
+DROP TABLE IF EXISTS review_revisions;
+
+DROP TABLE IF EXISTS reviews_archive;
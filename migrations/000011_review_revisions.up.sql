@@ -0,0 +1,100 @@
+-- Previous versions of a review, written whenever its rating or comment changes
+CREATE TABLE IF NOT EXISTS review_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    rating INT NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL,
+    replaced_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS review_revisions_review_id_idx ON review_revisions (review_id, replaced_at DESC);
+
+-- Reviews merged into a later review of the same user and business, kept with the reply they had
+CREATE TABLE IF NOT EXISTS reviews_archive (
+    id UUID PRIMARY KEY,
+    merged_into UUID REFERENCES reviews(id) ON DELETE SET NULL,
+    business_id UUID,
+    user_id UUID,
+    rating INT NOT NULL,
+    comment TEXT,
+    reply JSONB,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Keep only the latest review of each user per business. The earlier ones become its revisions,
+-- their attachments, votes and reply move to it and the rows themselves are archived.
+CREATE TEMPORARY TABLE review_merges AS
+SELECT id AS loser_id, latest_id AS keeper_id
+FROM (
+    SELECT
+        id,
+        FIRST_VALUE(id) OVER w AS latest_id,
+        ROW_NUMBER() OVER w AS rn
+    FROM reviews
+    WHERE business_id IS NOT NULL AND user_id IS NOT NULL
+    WINDOW w AS (PARTITION BY business_id, user_id ORDER BY created_at DESC, id)
+) ranked
+WHERE rn > 1;
+
+INSERT INTO reviews_archive (id, merged_into, business_id, user_id, rating, comment, reply, created_at, updated_at)
+SELECT r.id, m.keeper_id, r.business_id, r.user_id, r.rating, r.comment,
+    CASE WHEN rr.id IS NOT NULL THEN to_jsonb(rr) END, r.created_at, r.updated_at
+FROM review_merges m
+JOIN reviews r ON r.id = m.loser_id
+LEFT JOIN review_replies rr ON rr.review_id = r.id;
+
+INSERT INTO review_revisions (review_id, rating, comment, created_at)
+SELECT m.keeper_id, r.rating, r.comment, r.updated_at
+FROM review_merges m
+JOIN reviews r ON r.id = m.loser_id;
+
+UPDATE review_attachments a SET review_id = m.keeper_id
+FROM review_merges m
+WHERE a.review_id = m.loser_id;
+
+-- a user keeps one vote per review, the latest one when the kept review has none of theirs
+UPDATE review_votes v SET review_id = moved.keeper_id
+FROM (
+    SELECT DISTINCT ON (m.keeper_id, lv.user_id) lv.review_id, lv.user_id, m.keeper_id
+    FROM review_votes lv
+    JOIN review_merges m ON m.loser_id = lv.review_id
+    WHERE NOT EXISTS (SELECT 1 FROM review_votes k WHERE k.review_id = m.keeper_id AND k.user_id = lv.user_id)
+    ORDER BY m.keeper_id, lv.user_id, lv.updated_at DESC
+) moved
+WHERE v.review_id = moved.review_id AND v.user_id = moved.user_id;
+
+-- a review has one reply, the latest one moves when the kept review has none, the others stay archived
+UPDATE review_replies rr SET review_id = moved.keeper_id
+FROM (
+    SELECT DISTINCT ON (m.keeper_id) lr.id, m.keeper_id
+    FROM review_replies lr
+    JOIN review_merges m ON m.loser_id = lr.review_id
+    WHERE NOT EXISTS (SELECT 1 FROM review_replies k WHERE k.review_id = m.keeper_id)
+    ORDER BY m.keeper_id, lr.updated_at DESC
+) moved
+WHERE rr.id = moved.id;
+
+-- only votes the user also cast on the kept review and archived replies are left to cascade
+DELETE FROM reviews WHERE id IN (SELECT loser_id FROM review_merges);
+
+DROP TABLE review_merges;
+
+CREATE UNIQUE INDEX IF NOT EXISTS reviews_business_id_user_id_key ON reviews (business_id, user_id);
+
+CREATE OR REPLACE FUNCTION reviews_save_revision() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO review_revisions (review_id, rating, comment, created_at)
+    VALUES (OLD.id, OLD.rating, OLD.comment, OLD.updated_at);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_revision
+    AFTER UPDATE OF rating, comment ON reviews
+    FOR EACH ROW
+    WHEN (OLD.rating IS DISTINCT FROM NEW.rating OR OLD.comment IS DISTINCT FROM NEW.comment)
+    EXECUTE FUNCTION reviews_save_revision();
@@ -0,0 +1,42 @@
+DROP TRIGGER IF EXISTS reviews_business_rating ON reviews;
+CREATE TRIGGER reviews_business_rating
+    AFTER INSERT OR UPDATE OF business_id, rating OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION reviews_refresh_business_rating();
+
+CREATE OR REPLACE FUNCTION refresh_business_rating(p_business_id UUID) RETURNS void AS $$
+BEGIN
+    UPDATE businesses b SET
+        avg_rating = s.avg_rating,
+        review_count = s.review_count,
+        rating_histogram = s.rating_histogram
+    FROM (
+        SELECT
+            COALESCE(ROUND(AVG(rating), 2), 0) AS avg_rating,
+            COUNT(1) AS review_count,
+            ARRAY[
+                COUNT(1) FILTER (WHERE rating = 1),
+                COUNT(1) FILTER (WHERE rating = 2),
+                COUNT(1) FILTER (WHERE rating = 3),
+                COUNT(1) FILTER (WHERE rating = 4),
+                COUNT(1) FILTER (WHERE rating = 5)
+            ]::INT[] AS rating_histogram
+        FROM reviews
+        WHERE business_id = p_business_id
+    ) s
+    WHERE b.id = p_business_id;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS review_reports_count ON review_reports;
+DROP FUNCTION IF EXISTS review_reports_refresh_count();
+DROP TABLE IF EXISTS review_reports;
+
+DROP INDEX IF EXISTS reviews_status_idx;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS report_count,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS review_status;
+
+SELECT refresh_business_rating(id) FROM businesses;
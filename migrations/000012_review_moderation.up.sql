@@ -0,0 +1,72 @@
+CREATE TYPE review_status AS ENUM ('pending', 'published', 'hidden', 'removed');
+
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS status review_status NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS report_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS reviews_status_idx ON reviews (status);
+
+CREATE TABLE IF NOT EXISTS review_reports (
+    id UUID PRIMARY KEY,
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (review_id, user_id)
+);
+
+-- Keeps reviews.report_count equal to the number of unresolved reports
+CREATE OR REPLACE FUNCTION review_reports_refresh_count() RETURNS trigger AS $$
+DECLARE
+    v_review_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_review_id := OLD.review_id;
+    ELSE
+        v_review_id := NEW.review_id;
+    END IF;
+
+    UPDATE reviews SET report_count = (
+        SELECT COUNT(1) FROM review_reports WHERE review_id = v_review_id AND NOT resolved
+    )
+    WHERE id = v_review_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER review_reports_count
+    AFTER INSERT OR UPDATE OF resolved OR DELETE ON review_reports
+    FOR EACH ROW EXECUTE FUNCTION review_reports_refresh_count();
+
+-- Only published reviews count towards the rating of a business
+CREATE OR REPLACE FUNCTION refresh_business_rating(p_business_id UUID) RETURNS void AS $$
+BEGIN
+    UPDATE businesses b SET
+        avg_rating = s.avg_rating,
+        review_count = s.review_count,
+        rating_histogram = s.rating_histogram
+    FROM (
+        SELECT
+            COALESCE(ROUND(AVG(rating), 2), 0) AS avg_rating,
+            COUNT(1) AS review_count,
+            ARRAY[
+                COUNT(1) FILTER (WHERE rating = 1),
+                COUNT(1) FILTER (WHERE rating = 2),
+                COUNT(1) FILTER (WHERE rating = 3),
+                COUNT(1) FILTER (WHERE rating = 4),
+                COUNT(1) FILTER (WHERE rating = 5)
+            ]::INT[] AS rating_histogram
+        FROM reviews
+        WHERE business_id = p_business_id AND status = 'published'
+    ) s
+    WHERE b.id = p_business_id;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS reviews_business_rating ON reviews;
+CREATE TRIGGER reviews_business_rating
+    AFTER INSERT OR UPDATE OF business_id, rating, status OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION reviews_refresh_business_rating();
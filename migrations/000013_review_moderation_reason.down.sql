@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reviews_user_id_created_at_idx;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS moderation_reason;
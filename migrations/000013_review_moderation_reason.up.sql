@@ -0,0 +1,4 @@
+-- Why the automatic screening held the review for moderation
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderation_reason VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS reviews_user_id_created_at_idx ON reviews (user_id, created_at DESC);
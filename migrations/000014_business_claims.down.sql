@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS business_claim_events;
+DROP TABLE IF EXISTS business_claims;
+DROP TYPE IF EXISTS business_claim_status;
@@ -0,0 +1,29 @@
+CREATE TYPE business_claim_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE IF NOT EXISTS business_claims (
+    id UUID PRIMARY KEY,
+    business_id UUID NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status business_claim_status NOT NULL DEFAULT 'pending',
+    message TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- A user has at most one open claim per business
+CREATE UNIQUE INDEX IF NOT EXISTS business_claims_pending_key ON business_claims (business_id, user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS business_claims_status_idx ON business_claims (status, created_at);
+
+-- Audit trail of every step of a claim
+CREATE TABLE IF NOT EXISTS business_claim_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_id UUID NOT NULL REFERENCES business_claims(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(32) NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS business_claim_events_claim_id_idx ON business_claim_events (claim_id, created_at);
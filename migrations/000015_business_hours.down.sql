@@ -0,0 +1,20 @@
+DROP FUNCTION IF EXISTS business_is_open(JSONB, JSONB, TEXT, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS business_hours_contain(JSONB, TIME, BOOLEAN);
+DROP FUNCTION IF EXISTS business_day_hours(JSONB, JSONB, DATE);
+
+ALTER TABLE businesses
+    DROP COLUMN IF EXISTS time_zone,
+    DROP COLUMN IF EXISTS special_hours;
+
+CREATE FUNCTION business_hours_to_text(p_hours JSONB) RETURNS JSON AS $$
+    SELECT json_object_agg(d.day, COALESCE((
+        SELECT string_agg((r->>'open') || '-' || (r->>'close'), ', ')
+        FROM jsonb_array_elements(p_hours->d.day) r
+    ), ''))
+    FROM unnest(ARRAY['monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday']) AS d(day)
+    WHERE p_hours IS NOT NULL;
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE businesses ALTER COLUMN hours_of_operation TYPE JSON USING business_hours_to_text(hours_of_operation);
+
+DROP FUNCTION business_hours_to_text(JSONB);
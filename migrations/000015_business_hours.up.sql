@@ -0,0 +1,55 @@
+-- Opening hours become a list of {"open": "HH:MM", "close": "HH:MM"} ranges per weekday,
+-- a range closing at or before its opening time runs past midnight
+CREATE FUNCTION business_hours_from_text(p_hours JSON) RETURNS JSONB AS $$
+    SELECT jsonb_object_agg(d.day, COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('open', lpad(m[1], 5, '0'), 'close', lpad(m[2], 5, '0')))
+        FROM regexp_matches(p_hours->>d.day, '(\d{1,2}:\d{2})\s*-\s*(\d{1,2}:\d{2})', 'g') AS m
+    ), '[]'::jsonb))
+    FROM unnest(ARRAY['monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday']) AS d(day)
+    WHERE p_hours IS NOT NULL;
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE businesses ALTER COLUMN hours_of_operation TYPE JSONB USING business_hours_from_text(hours_of_operation);
+
+DROP FUNCTION business_hours_from_text(JSON);
+
+ALTER TABLE businesses
+    ADD COLUMN IF NOT EXISTS special_hours JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Ranges of a local date: a special day (holiday, event) overrides the regular weekday
+CREATE OR REPLACE FUNCTION business_day_hours(p_hours JSONB, p_special JSONB, p_date DATE) RETURNS JSONB AS $$
+    SELECT COALESCE(
+        (
+            SELECT CASE WHEN COALESCE((s->>'closed')::boolean, false) THEN '[]'::jsonb ELSE COALESCE(s->'hours', '[]'::jsonb) END
+            FROM jsonb_array_elements(COALESCE(p_special, '[]'::jsonb)) s
+            WHERE s->>'date' = to_char(p_date, 'YYYY-MM-DD')
+            LIMIT 1
+        ),
+        p_hours->((ARRAY['monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday'])[EXTRACT(ISODOW FROM p_date)::int]),
+        '[]'::jsonb
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Whether one of the ranges contains the time, or with p_carried only the part of overnight ranges after midnight
+CREATE OR REPLACE FUNCTION business_hours_contain(p_ranges JSONB, p_time TIME, p_carried BOOLEAN) RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM jsonb_array_elements(p_ranges) r,
+            LATERAL (SELECT (r->>'open')::time AS opens, (r->>'close')::time AS closes) h
+        WHERE CASE
+            WHEN p_carried THEN h.closes <= h.opens AND p_time < h.closes
+            WHEN h.closes > h.opens THEN p_time >= h.opens AND p_time < h.closes
+            ELSE p_time >= h.opens
+        END
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION business_is_open(p_hours JSONB, p_special JSONB, p_time_zone TEXT, p_at TIMESTAMPTZ) RETURNS BOOLEAN AS $$
+DECLARE
+    v_local TIMESTAMP := p_at AT TIME ZONE COALESCE(NULLIF(p_time_zone, ''), 'UTC');
+BEGIN
+    RETURN business_hours_contain(business_day_hours(p_hours, p_special, v_local::date), v_local::time, false)
+        OR business_hours_contain(business_day_hours(p_hours, p_special, v_local::date - 1), v_local::time, true);
+END;
+$$ LANGUAGE plpgsql STABLE;
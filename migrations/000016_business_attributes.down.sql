@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS businesses_attributes_idx;
+DROP INDEX IF EXISTS businesses_price_level_idx;
+
+ALTER TABLE businesses
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS price_level;
+
+DROP TABLE IF EXISTS business_category_attributes;
+
+DROP TYPE IF EXISTS business_attribute_type;
@@ -0,0 +1,21 @@
+CREATE TYPE business_attribute_type AS ENUM ('boolean', 'enum');
+
+-- Attributes a business of the category can describe itself with (wifi, parking, noise level, ...)
+CREATE TABLE IF NOT EXISTS business_category_attributes (
+    id UUID PRIMARY KEY,
+    category_id UUID NOT NULL REFERENCES business_categories(id) ON DELETE CASCADE,
+    key VARCHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type business_attribute_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (category_id, key)
+);
+
+ALTER TABLE businesses
+    ADD COLUMN IF NOT EXISTS price_level SMALLINT CHECK (price_level BETWEEN 1 AND 4),
+    ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS businesses_price_level_idx ON businesses (price_level);
+CREATE INDEX IF NOT EXISTS businesses_attributes_idx ON businesses USING GIN (attributes jsonb_path_ops);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS business_category_links;
+
+DROP INDEX IF EXISTS business_categories_parent_id_idx;
+
+ALTER TABLE business_categories DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,17 @@
+ALTER TABLE business_categories
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES business_categories(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS business_categories_parent_id_idx ON business_categories (parent_id);
+
+-- Every category of a business, businesses.category_id stays as the primary one and is always listed here
+CREATE TABLE IF NOT EXISTS business_category_links (
+    business_id UUID NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES business_categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (business_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS business_category_links_category_id_idx ON business_category_links (category_id);
+
+INSERT INTO business_category_links (business_id, category_id)
+SELECT id, category_id FROM businesses WHERE category_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS businesses_address_trgm_idx;
+DROP INDEX IF EXISTS businesses_name_trgm_idx;
+DROP INDEX IF EXISTS reviews_search_vector_idx;
+DROP INDEX IF EXISTS businesses_search_vector_idx;
+
+DROP TRIGGER IF EXISTS reviews_search_vector ON reviews;
+DROP FUNCTION IF EXISTS reviews_search_vector();
+DROP TRIGGER IF EXISTS businesses_search_vector ON businesses;
+DROP FUNCTION IF EXISTS businesses_search_vector();
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE businesses DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
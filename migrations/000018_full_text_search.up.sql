@@ -0,0 +1,43 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+-- The name weighs most, then the description, then the address
+CREATE OR REPLACE FUNCTION businesses_search_vector() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.address, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER businesses_search_vector
+    BEFORE INSERT OR UPDATE OF name, description, address ON businesses
+    FOR EACH ROW EXECUTE FUNCTION businesses_search_vector();
+
+CREATE OR REPLACE FUNCTION reviews_search_vector() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', COALESCE(NEW.comment, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_search_vector
+    BEFORE INSERT OR UPDATE OF comment ON reviews
+    FOR EACH ROW EXECUTE FUNCTION reviews_search_vector();
+
+UPDATE businesses SET search_vector =
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(address, '')), 'C');
+UPDATE reviews SET search_vector = to_tsvector('english', COALESCE(comment, ''));
+
+CREATE INDEX IF NOT EXISTS businesses_search_vector_idx ON businesses USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS reviews_search_vector_idx ON reviews USING GIN (search_vector);
+
+-- Trigram indexes serve the typo tolerant matching of names and addresses
+CREATE INDEX IF NOT EXISTS businesses_name_trgm_idx ON businesses USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS businesses_address_trgm_idx ON businesses USING GIN (address gin_trgm_ops);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS business_categories_name_trgm_idx;
+
+DROP TABLE IF EXISTS search_queries;
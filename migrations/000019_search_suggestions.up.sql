@@ -0,0 +1,11 @@
+-- Searches that found something, suggested to later searchers by popularity
+CREATE TABLE IF NOT EXISTS search_queries (
+    query VARCHAR(200) PRIMARY KEY,
+    hits INT NOT NULL DEFAULT 1,
+    last_searched_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS search_queries_query_prefix_idx ON search_queries (query text_pattern_ops);
+CREATE INDEX IF NOT EXISTS search_queries_hits_idx ON search_queries (hits DESC);
+
+CREATE INDEX IF NOT EXISTS business_categories_name_trgm_idx ON business_categories USING GIN (name gin_trgm_ops);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS collections_user_id_idx ON collections (user_id, created_at);
+-- The bookmarks collection, created on the first save
+CREATE UNIQUE INDEX IF NOT EXISTS collections_default_key ON collections (user_id) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    business_id UUID NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, business_id)
+);
+
+CREATE INDEX IF NOT EXISTS collection_items_position_idx ON collection_items (collection_id, position);
+CREATE INDEX IF NOT EXISTS collection_items_business_id_idx ON collection_items (business_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS review_attachments_review_id_idx;
+
+DROP TABLE IF EXISTS user_follows;
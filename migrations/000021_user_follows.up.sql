@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_follows_followee_id_idx ON user_follows (followee_id, created_at DESC);
+
+-- The feed reads the newest photos of the reviews of followed users
+CREATE INDEX IF NOT EXISTS review_attachments_review_id_idx ON review_attachments (review_id, created_at DESC);
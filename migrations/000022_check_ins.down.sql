@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS check_ins_business_count ON check_ins;
+DROP FUNCTION IF EXISTS check_ins_refresh_business_count();
+
+ALTER TABLE businesses DROP COLUMN IF EXISTS check_in_count;
+
+DROP TABLE IF EXISTS check_ins;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS check_ins (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    business_id UUID NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    distance_m DOUBLE PRECISION,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS check_ins_user_id_idx ON check_ins (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS check_ins_business_id_idx ON check_ins (business_id, user_id, created_at DESC);
+
+ALTER TABLE businesses ADD COLUMN IF NOT EXISTS check_in_count INT NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION check_ins_refresh_business_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE businesses SET check_in_count = check_in_count + 1 WHERE id = NEW.business_id;
+    ELSE
+        UPDATE businesses SET check_in_count = check_in_count - 1 WHERE id = OLD.business_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS check_ins_business_count ON check_ins;
+CREATE TRIGGER check_ins_business_count
+    AFTER INSERT OR DELETE ON check_ins
+    FOR EACH ROW EXECUTE FUNCTION check_ins_refresh_business_count();
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS businesses_latitude_longitude_idx;
@@ -0,0 +1,2 @@
+-- Bounding box searches compare the raw coordinates
+CREATE INDEX IF NOT EXISTS businesses_latitude_longitude_idx ON businesses (latitude, longitude);
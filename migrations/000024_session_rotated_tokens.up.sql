@@ -0,0 +1,8 @@
+-- hashes of the refresh tokens a session rotated away from, presenting one of them again means the token leaked
+CREATE TABLE IF NOT EXISTS session_rotated_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES session(id) ON DELETE CASCADE,
+    rotated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS session_rotated_tokens_session_id_idx ON session_rotated_tokens (session_id);
@@ -0,0 +1,27 @@
+-- Ranges of a local date: a special day (holiday, event) overrides the regular weekday
+CREATE OR REPLACE FUNCTION business_day_hours(p_hours JSONB, p_special JSONB, p_date DATE) RETURNS JSONB AS $$
+    SELECT COALESCE(
+        (
+            SELECT CASE WHEN COALESCE((s->>'closed')::boolean, false) THEN '[]'::jsonb ELSE COALESCE(s->'hours', '[]'::jsonb) END
+            FROM jsonb_array_elements(COALESCE(p_special, '[]'::jsonb)) s
+            WHERE s->>'date' = to_char(p_date, 'YYYY-MM-DD')
+            LIMIT 1
+        ),
+        p_hours->((ARRAY['monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday'])[EXTRACT(ISODOW FROM p_date)::int]),
+        '[]'::jsonb
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Whether one of the ranges contains the time, or with p_carried only the part of overnight ranges after midnight
+CREATE OR REPLACE FUNCTION business_hours_contain(p_ranges JSONB, p_time TIME, p_carried BOOLEAN) RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM jsonb_array_elements(p_ranges) r,
+            LATERAL (SELECT (r->>'open')::time AS opens, (r->>'close')::time AS closes) h
+        WHERE CASE
+            WHEN p_carried THEN h.closes <= h.opens AND p_time < h.closes
+            WHEN h.closes > h.opens THEN p_time >= h.opens AND p_time < h.closes
+            ELSE p_time >= h.opens
+        END
+    );
+$$ LANGUAGE sql IMMUTABLE;
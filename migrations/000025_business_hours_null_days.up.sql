@@ -0,0 +1,50 @@
+-- Days and special days stored as JSON null instead of a list of ranges are closed
+UPDATE businesses SET hours_of_operation = (
+    SELECT jsonb_object_agg(d.key, CASE WHEN jsonb_typeof(d.value) = 'array' THEN d.value ELSE '[]'::jsonb END)
+    FROM jsonb_each(hours_of_operation) d
+)
+WHERE jsonb_typeof(hours_of_operation) = 'object'
+    AND EXISTS (SELECT 1 FROM jsonb_each(hours_of_operation) d WHERE jsonb_typeof(d.value) <> 'array');
+
+UPDATE businesses SET special_hours = (
+    SELECT COALESCE(jsonb_agg(
+        CASE WHEN jsonb_typeof(s->'hours') = 'array' THEN s ELSE s || '{"hours": []}'::jsonb END
+        ORDER BY n
+    ), '[]'::jsonb)
+    FROM jsonb_array_elements(special_hours) WITH ORDINALITY AS e(s, n)
+)
+WHERE jsonb_typeof(special_hours) = 'array'
+    AND EXISTS (SELECT 1 FROM jsonb_array_elements(special_hours) s WHERE jsonb_typeof(s->'hours') IS DISTINCT FROM 'array');
+
+-- the server zone of the API ("Local") means nothing to the database
+UPDATE businesses SET time_zone = 'UTC'
+WHERE time_zone NOT IN (SELECT name FROM pg_timezone_names);
+
+-- Anything but a list of ranges, such as a JSON null day, counts as closed
+CREATE OR REPLACE FUNCTION business_day_hours(p_hours JSONB, p_special JSONB, p_date DATE) RETURNS JSONB AS $$
+    SELECT CASE WHEN jsonb_typeof(h.ranges) = 'array' THEN h.ranges ELSE '[]'::jsonb END
+    FROM (
+        SELECT COALESCE(
+            (
+                SELECT CASE WHEN COALESCE((s->>'closed')::boolean, false) THEN '[]'::jsonb ELSE COALESCE(s->'hours', '[]'::jsonb) END
+                FROM jsonb_array_elements(CASE WHEN jsonb_typeof(p_special) = 'array' THEN p_special ELSE '[]'::jsonb END) s
+                WHERE s->>'date' = to_char(p_date, 'YYYY-MM-DD')
+                LIMIT 1
+            ),
+            p_hours->((ARRAY['monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday'])[EXTRACT(ISODOW FROM p_date)::int])
+        ) AS ranges
+    ) h;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION business_hours_contain(p_ranges JSONB, p_time TIME, p_carried BOOLEAN) RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(p_ranges) = 'array' THEN p_ranges ELSE '[]'::jsonb END) r,
+            LATERAL (SELECT (r->>'open')::time AS opens, (r->>'close')::time AS closes) h
+        WHERE CASE
+            WHEN p_carried THEN h.closes <= h.opens AND p_time < h.closes
+            WHEN h.closes > h.opens THEN p_time >= h.opens AND p_time < h.closes
+            ELSE p_time >= h.opens
+        END
+    );
+$$ LANGUAGE sql IMMUTABLE;
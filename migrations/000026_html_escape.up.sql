@@ -0,0 +1,5 @@
+-- Escapes text for use in HTML, e.g. before ts_headline wraps the matches of user written text in tags
+CREATE OR REPLACE FUNCTION html_escape(p_text TEXT) RETURNS TEXT AS $$
+    SELECT replace(replace(replace(replace(replace(p_text,
+        '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;');
+$$ LANGUAGE sql IMMUTABLE;
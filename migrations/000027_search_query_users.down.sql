@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS search_queries_popularity_idx;
+CREATE INDEX IF NOT EXISTS search_queries_hits_idx ON search_queries (hits DESC);
+
+ALTER TABLE search_queries DROP COLUMN IF EXISTS user_count;
+
+DROP TABLE IF EXISTS search_query_users;
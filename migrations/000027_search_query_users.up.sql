@@ -0,0 +1,13 @@
+-- Who ran a search, a search becomes a suggestion only once enough distinct users ran it
+CREATE TABLE IF NOT EXISTS search_query_users (
+    query VARCHAR(200) NOT NULL REFERENCES search_queries(query) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_searched_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (query, user_id)
+);
+
+-- searches recorded so far have no known users, they are not suggested until users search them again
+ALTER TABLE search_queries ADD COLUMN IF NOT EXISTS user_count INT NOT NULL DEFAULT 0;
+
+DROP INDEX IF EXISTS search_queries_hits_idx;
+CREATE INDEX IF NOT EXISTS search_queries_popularity_idx ON search_queries (user_count DESC, hits DESC);
@@ -0,0 +1 @@
+ALTER TABLE businesses DROP CONSTRAINT IF EXISTS businesses_coordinates_check;
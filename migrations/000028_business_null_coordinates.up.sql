@@ -0,0 +1,6 @@
+-- businesses created without coordinates were stored at 0, 0
+UPDATE businesses SET latitude = NULL, longitude = NULL WHERE latitude = 0 AND longitude = 0;
+UPDATE businesses SET latitude = NULL, longitude = NULL WHERE latitude IS NULL OR longitude IS NULL;
+
+ALTER TABLE businesses
+    ADD CONSTRAINT businesses_coordinates_check CHECK ((latitude IS NULL) = (longitude IS NULL));
@@ -0,0 +1 @@
+-- the removed self votes are not restored
@@ -0,0 +1,4 @@
+-- authors can no longer vote on their own reviews, the trigger recounts the reviews they voted on
+DELETE FROM review_votes v
+USING reviews r
+WHERE r.id = v.review_id AND r.user_id = v.user_id;
@@ -0,0 +1 @@
+-- the constant defaults are not restored, they were never meant to be constant
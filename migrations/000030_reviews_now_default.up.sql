@@ -0,0 +1,8 @@
+-- the quoted 'now()' defaults were turned into the time the tables were created, so every row got the same timestamp
+ALTER TABLE reviews
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN updated_at SET DEFAULT now();
+
+ALTER TABLE review_attachments
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN updated_at SET DEFAULT now();
@@ -0,0 +1,18 @@
+package etc
+
+import "math"
+
+// earthRadiusM matches the radius of the earthdistance extension, so distances agree with the database
+const earthRadiusM = 6378168.0
+
+// DistanceM returns the great circle distance in meters between two points given in degrees
+func DistanceM(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,8 @@
+package etc
+
+import "strings"
+
+// NormalizeQuery lower-cases a search query and collapses its whitespace
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
@@ -0,0 +1,99 @@
+// Package imaging implements resizing of uploaded photos into variants.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// Registered decoders of the accepted photo formats.
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const _defaultQuality = 85
+
+// ErrTooLarge is returned for images with more pixels than allowed, they are rejected before being decoded.
+var ErrTooLarge = errors.New("image is too large")
+
+// Variant describes one generated size of a photo.
+type Variant struct {
+	Name string
+	// MaxSide bounds the longest side of the variant, images are never upscaled.
+	MaxSide int
+	// Square center-crops the image before resizing.
+	Square bool
+}
+
+// DefaultVariants -.
+var DefaultVariants = []Variant{
+	{Name: "thumbnail", MaxSide: 150, Square: true},
+	{Name: "medium", MaxSide: 600},
+	{Name: "large", MaxSide: 1200},
+}
+
+// GenerateVariants decodes the photo read from r and returns every variant encoded as JPEG, keyed by name.
+// The dimensions are read from the header first, a photo of more than maxPixels pixels is never decoded.
+func GenerateVariants(r io.Reader, variants []Variant, maxPixels int64) (map[string][]byte, error) {
+	// the header read by DecodeConfig is kept and read again by Decode
+	var head bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, fmt.Errorf("imaging - GenerateVariants - image.DecodeConfig: %w", err)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, fmt.Errorf("imaging - GenerateVariants - %dx%d: %w", cfg.Width, cfg.Height, ErrTooLarge)
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&head, r))
+	if err != nil {
+		return nil, fmt.Errorf("imaging - GenerateVariants - image.Decode: %w", err)
+	}
+
+	response := make(map[string][]byte, len(variants))
+
+	for _, v := range variants {
+		var buf bytes.Buffer
+
+		err = jpeg.Encode(&buf, resize(src, v), &jpeg.Options{Quality: _defaultQuality})
+		if err != nil {
+			return nil, fmt.Errorf("imaging - GenerateVariants - jpeg.Encode: %w", err)
+		}
+
+		response[v.Name] = buf.Bytes()
+	}
+
+	return response, nil
+}
+
+func resize(src image.Image, v Variant) image.Image {
+	bounds := src.Bounds()
+
+	if v.Square {
+		side := min(bounds.Dx(), bounds.Dy())
+		x := bounds.Min.X + (bounds.Dx()-side)/2
+		y := bounds.Min.Y + (bounds.Dy()-side)/2
+		bounds = image.Rect(x, y, x+side, y+side)
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); longest > v.MaxSide {
+		width = max(1, width*v.MaxSide/longest)
+		height = max(1, height*v.MaxSide/longest)
+	}
+
+	// JPEG has no alpha channel, so transparent areas are flattened onto white
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	return dst
+}
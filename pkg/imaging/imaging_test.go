@@ -0,0 +1,88 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestGenerateVariants(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		maxPixels int64
+		wantSizes map[string]image.Point
+		wantErr   error
+	}{
+		{
+			name:      "landscape",
+			data:      encodePNG(t, 800, 400),
+			maxPixels: 800 * 400,
+			wantSizes: map[string]image.Point{
+				"thumbnail": {150, 150},
+				"medium":    {600, 300},
+				"large":     {800, 400},
+			},
+		},
+		{
+			name:      "over the pixel limit",
+			data:      encodePNG(t, 800, 400),
+			maxPixels: 800*400 - 1,
+			wantErr:   ErrTooLarge,
+		},
+		{
+			// the header claims a huge image, decoding it would allocate gigabytes
+			name:      "decompression bomb",
+			data:      bombPNG(t),
+			maxPixels: 40_000_000,
+			wantErr:   ErrTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants, err := GenerateVariants(bytes.NewReader(tt.data), DefaultVariants, tt.maxPixels)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateVariants() error = %v, want %v", err, tt.wantErr)
+			}
+
+			for name, want := range tt.wantSizes {
+				cfg, _, err := image.DecodeConfig(bytes.NewReader(variants[name]))
+				if err != nil {
+					t.Fatalf("variant %s: %v", name, err)
+				}
+				if got := (image.Point{cfg.Width, cfg.Height}); got != want {
+					t.Errorf("variant %s = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// bombPNG rewrites the header of a small PNG to claim 100000x100000 pixels
+func bombPNG(t *testing.T) []byte {
+	t.Helper()
+
+	data := encodePNG(t, 1, 1)
+	// the IHDR width and height follow the 8 byte signature, chunk length and type
+	copy(data[16:24], []byte{0, 1, 0x86, 0xa0, 0, 1, 0x86, 0xa0})
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	return data
+}
@@ -2,18 +2,29 @@ package jwt
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// ErrTokenExpired is returned by ParseJWT for tokens past their exp claim.
+var ErrTokenExpired = jwt.ErrTokenExpired
+
 type JwtGenerateRequest struct {
 	Keys      map[string]interface{} `json:"keys"`
 	JwtKey    string
 	ExpiresAt int64 `json:"expires_at"`
 }
 
-func GenerateJWT(keys map[string]interface{}, jwtKey string) (string, error) {
-	claims := jwt.MapClaims{}
+// GenerateJWT signs the given keys together with iat, exp and a unique jti claim.
+func GenerateJWT(keys map[string]interface{}, jwtKey string, expiresIn time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iat": now.Unix(),
+		"exp": now.Add(expiresIn).Unix(),
+		"jti": uuid.NewString(),
+	}
 
 	for key, value := range keys {
 		claims[key] = value
@@ -37,7 +48,7 @@ func ParseJWT(tokenString string, jwtKey string) (jwt.MapClaims, error) {
 
 		// Return the secret key
 		return []byte(jwtKey), nil
-	})
+	}, jwt.WithExpirationRequired(), jwt.WithIssuedAt())
 
 	if err != nil {
 		return nil, err
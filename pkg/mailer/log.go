@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/abdulazizax/yelp/pkg/logger"
+)
+
+// Log only writes messages to the logger, for local development without an SMTP server.
+type Log struct {
+	logger logger.Interface
+}
+
+var _ Mailer = (*Log)(nil)
+
+// NewLog -.
+func NewLog(l logger.Interface) *Log {
+	return &Log{logger: l}
+}
+
+// Send -.
+func (l *Log) Send(_ context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	l.logger.Info("mailer - Log - Send: to=%s subject=%q\n%s", strings.Join(msg.To, ","), msg.Subject, msg.Text)
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// Package mailer implements email delivery with interchangeable transports.
+package mailer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoRecipients -.
+var ErrNoRecipients = errors.New("mailer: message has no recipients")
+
+// Message -.
+type Message struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	HTML    string   `json:"html"`
+	// Text is the plain text alternative of HTML.
+	Text string `json:"text"`
+}
+
+// Mailer -.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps sent messages in memory so tests can assert on them.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+var _ Mailer = (*Memory)(nil)
+
+// NewMemory -.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Send -.
+func (m *Memory) Send(_ context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+
+	return nil
+}
+
+// Messages returns a copy of every message sent so far.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
+
+// Last returns the most recent message sent to the recipient.
+func (m *Memory) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		for _, recipient := range m.messages[i].To {
+			if recipient == to {
+				return m.messages[i], true
+			}
+		}
+	}
+
+	return Message{}, false
+}
+
+// Reset forgets all sent messages.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// JobSend is the queue job type of a message waiting for delivery.
+const JobSend = "mailer.send"
+
+// Publisher -.
+type Publisher interface {
+	Publish(ctx context.Context, jobType string, body []byte) error
+}
+
+// Queue hands messages over to a background worker instead of sending them within the caller's request.
+type Queue struct {
+	publisher Publisher
+}
+
+var _ Mailer = (*Queue)(nil)
+
+// NewQueue -.
+func NewQueue(p Publisher) *Queue {
+	return &Queue{publisher: p}
+}
+
+// Send -.
+func (q *Queue) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("mailer - Queue - Send - json.Marshal: %w", err)
+	}
+
+	return q.publisher.Publish(ctx, JobSend, body)
+}
+
+// Deliver returns the worker job that sends queued messages through m.
+func Deliver(m Mailer) func(ctx context.Context, body []byte) error {
+	return func(ctx context.Context, body []byte) error {
+		var msg Message
+
+		err := json.Unmarshal(body, &msg)
+		if err != nil {
+			return fmt.Errorf("mailer - Deliver - json.Unmarshal: %w", err)
+		}
+
+		return m.Send(ctx, msg)
+	}
+}
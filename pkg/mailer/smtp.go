@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+// SMTPConfig -.
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password are optional, local stand-ins like MailHog accept unauthenticated mail.
+	Username string
+	Password string
+	From     string
+}
+
+// SMTP sends messages through an SMTP server.
+type SMTP struct {
+	cfg SMTPConfig
+}
+
+var _ Mailer = (*SMTP)(nil)
+
+// NewSMTP -.
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+
+	return &SMTP{cfg: cfg}
+}
+
+// Send -.
+func (s *SMTP) Send(_ context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	body, err := buildMIME(s.cfg.From, msg)
+	if err != nil {
+		return fmt.Errorf("mailer - SMTP - Send - buildMIME: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	err = smtp.SendMail(s.cfg.Host+":"+s.cfg.Port, auth, s.cfg.From, msg.To, body)
+	if err != nil {
+		return fmt.Errorf("mailer - SMTP - Send - smtp.SendMail: %w", err)
+	}
+
+	return nil
+}
+
+// buildMIME renders msg as a multipart/alternative email with text and HTML parts
+func buildMIME(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=\"UTF-8\"", body: msg.Text},
+		{contentType: "text/html; charset=\"UTF-8\"", body: msg.HTML},
+	}
+
+	for _, p := range parts {
+		if p.body == "" {
+			continue
+		}
+
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(part)
+		_, err = qp.Write([]byte(p.body))
+		if err != nil {
+			return nil, err
+		}
+
+		err = qp.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,257 @@
+package mailer
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+// Template names.
+const (
+	TemplateOtp           = "otp"
+	TemplateWelcome       = "welcome"
+	TemplatePasswordReset = "password_reset"
+	TemplateSecurityAlert = "security_alert"
+)
+
+// Security alert events, passed as Data.Event of TemplateSecurityAlert.
+const (
+	EventPasswordChanged = "password_changed"
+	EventSessionRevoked  = "session_revoked"
+)
+
+// Data is the data the default templates are rendered with.
+type Data struct {
+	Name      string
+	Code      string
+	ExpiresIn int // minutes
+	Event     string
+}
+
+// Template is the localized content of one email. Every field is a text/template executed with the render data.
+type Template struct {
+	Subject    string
+	Title      string
+	Paragraphs []string
+	// Code is shown highlighted after the first paragraph, e.g. "{{.Code}}" for one-time passwords.
+	Code string
+}
+
+// Templates is a registry of localized email templates.
+type Templates struct {
+	defaultLocale string
+	templates     map[string]map[string]parsedTemplate
+}
+
+// parsedTemplate holds the fields of a Template parsed once at registration, an empty field is nil
+type parsedTemplate struct {
+	subject    *template.Template
+	title      *template.Template
+	paragraphs []*template.Template
+	code       *template.Template
+}
+
+// NewTemplates -.
+func NewTemplates(defaultLocale string) *Templates {
+	return &Templates{
+		defaultLocale: defaultLocale,
+		templates:     map[string]map[string]parsedTemplate{},
+	}
+}
+
+// Register parses tmpl and adds or replaces it as the template name for locale.
+func (t *Templates) Register(name, locale string, tmpl Template) error {
+	parsed, err := parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("mailer - Templates - Register %q %q: %w", name, locale, err)
+	}
+
+	if t.templates[name] == nil {
+		t.templates[name] = map[string]parsedTemplate{}
+	}
+
+	t.templates[name][strings.ToLower(locale)] = parsed
+
+	return nil
+}
+
+// Render builds the subject, HTML and plain text of the template name. The locale falls back
+// from "uz-Latn" to "uz" and then to the default locale.
+func (t *Templates) Render(name, locale string, data interface{}) (Message, error) {
+	locales, ok := t.templates[name]
+	if !ok {
+		return Message{}, fmt.Errorf("mailer - Templates - Render: unknown template %q", name)
+	}
+
+	locale = strings.ToLower(locale)
+	base, _, _ := strings.Cut(locale, "-")
+
+	tmpl, ok := locales[locale]
+	if !ok {
+		tmpl, ok = locales[base]
+	}
+	if !ok {
+		tmpl, ok = locales[t.defaultLocale]
+	}
+	if !ok {
+		return Message{}, fmt.Errorf("mailer - Templates - Render: template %q has no %q translation", name, t.defaultLocale)
+	}
+
+	content, err := execute(tmpl, data)
+	if err != nil {
+		return Message{}, fmt.Errorf("mailer - Templates - Render - execute: %w", err)
+	}
+
+	var html, text strings.Builder
+
+	err = htmlLayout.Execute(&html, content)
+	if err != nil {
+		return Message{}, fmt.Errorf("mailer - Templates - Render - htmlLayout.Execute: %w", err)
+	}
+
+	err = textLayout.Execute(&text, content)
+	if err != nil {
+		return Message{}, fmt.Errorf("mailer - Templates - Render - textLayout.Execute: %w", err)
+	}
+
+	return Message{
+		Subject: content.Subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+func parse(tmpl Template) (parsedTemplate, error) {
+	var err error
+
+	field := func(s string) *template.Template {
+		if err != nil || s == "" {
+			return nil
+		}
+
+		var t *template.Template
+		t, err = template.New("").Option("missingkey=zero").Parse(s)
+		return t
+	}
+
+	response := parsedTemplate{
+		subject: field(tmpl.Subject),
+		title:   field(tmpl.Title),
+		code:    field(tmpl.Code),
+	}
+	for _, p := range tmpl.Paragraphs {
+		response.paragraphs = append(response.paragraphs, field(p))
+	}
+
+	return response, err
+}
+
+// execute fills the template fields with data, the result is escaped later by the HTML layout
+func execute(tmpl parsedTemplate, data interface{}) (Template, error) {
+	var err error
+
+	render := func(t *template.Template) string {
+		if err != nil || t == nil {
+			return ""
+		}
+
+		var builder strings.Builder
+		err = t.Execute(&builder, data)
+		return builder.String()
+	}
+
+	response := Template{
+		Subject: render(tmpl.subject),
+		Title:   render(tmpl.title),
+		Code:    render(tmpl.code),
+	}
+	for _, p := range tmpl.paragraphs {
+		response.Paragraphs = append(response.Paragraphs, render(p))
+	}
+
+	return response, err
+}
+
+var htmlLayout = htmltemplate.Must(htmltemplate.New("html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            margin: 0;
+            padding: 0;
+            background-color: #f4f7fa;
+        }
+        .email-container {
+            width: 100%;
+            max-width: 600px;
+            margin: 0 auto;
+            background-color: #ffffff;
+            padding: 20px;
+            border-radius: 8px;
+            box-shadow: 0 4px 8px rgba(0, 0, 0, 0.1);
+        }
+        .email-header {
+            text-align: center;
+            margin-bottom: 20px;
+        }
+        .email-header h2 {
+            color: #333333;
+        }
+        .email-body {
+            font-size: 16px;
+            color: #555555;
+            line-height: 1.5;
+        }
+        .otp-code {
+            font-size: 20px;
+            font-weight: bold;
+            color: #007BFF;
+            padding: 10px;
+            background-color: #f0f8ff;
+            border-radius: 4px;
+            margin-top: 15px;
+        }
+        .footer {
+            text-align: center;
+            margin-top: 30px;
+            font-size: 14px;
+            color: #888888;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="email-header">
+            <h2>{{.Title}}</h2>
+        </div>
+        <div class="email-body">
+            {{- range $i, $p := .Paragraphs}}
+            <p>{{$p}}</p>
+            {{- if and (eq $i 0) $.Code}}
+            <div class="otp-code">{{$.Code}}</div>
+            {{- end}}
+            {{- end}}
+        </div>
+        <div class="footer">
+            <p>&copy; 2025 Yelp. All rights reserved.</p>
+        </div>
+    </div>
+</body>
+</html>
+`))
+
+var textLayout = template.Must(template.New("text").Parse(`{{.Title}}
+{{range $i, $p := .Paragraphs}}
+{{$p}}
+{{- if and (eq $i 0) $.Code}}
+
+    {{$.Code}}
+{{- end}}
+{{end}}
+-- 
+© 2025 Yelp. All rights reserved.
+`))
@@ -0,0 +1,130 @@
+package mailer
+
+// NewDefaultTemplates returns the registry with the built-in templates in English, Russian and Uzbek.
+func NewDefaultTemplates(defaultLocale string) *Templates {
+	t := NewTemplates(defaultLocale)
+
+	// the built-in templates are constants, one that does not parse is a bug
+	register := func(name, locale string, tmpl Template) {
+		err := t.Register(name, locale, tmpl)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// English
+	register(TemplateOtp, "en", Template{
+		Subject: "Your Yelp verification code",
+		Title:   "Welcome to Yelp!",
+		Paragraphs: []string{
+			"Thank you for signing up to Yelp! To complete your registration and verify your account, please use the OTP (One-Time Password) below:",
+			"This code is valid for {{.ExpiresIn}} minutes. If you did not request this, please ignore this email.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateWelcome, "en", Template{
+		Subject: "Welcome to Yelp, {{.Name}}!",
+		Title:   "Your account is ready",
+		Paragraphs: []string{
+			"Hi {{.Name}}, your email address has been verified and your Yelp account is now active.",
+			"Start exploring local businesses and share your experience by writing reviews.",
+		},
+	})
+	register(TemplatePasswordReset, "en", Template{
+		Subject: "Your Yelp password reset code",
+		Title:   "Reset your Yelp password",
+		Paragraphs: []string{
+			"We received a request to reset the password of your Yelp account. To choose a new password, please use the OTP (One-Time Password) below:",
+			"This code is valid for {{.ExpiresIn}} minutes. If you did not request a password reset, please ignore this email.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateSecurityAlert, "en", Template{
+		Subject: "Security alert for your Yelp account",
+		Title:   "Security alert",
+		Paragraphs: []string{
+			`{{if eq .Event "password_changed"}}The password of your Yelp account was changed and all of your sessions were signed out.` +
+				`{{else if eq .Event "session_revoked"}}A sign-in token of your Yelp account was used twice, so the session it belonged to was signed out.` +
+				`{{else}}There was a security related change on your Yelp account.{{end}}`,
+			"If this was you, no action is needed. Otherwise please reset your password immediately.",
+		},
+	})
+
+	// Russian
+	register(TemplateOtp, "ru", Template{
+		Subject: "Код подтверждения Yelp",
+		Title:   "Добро пожаловать в Yelp!",
+		Paragraphs: []string{
+			"Спасибо за регистрацию в Yelp! Чтобы завершить регистрацию и подтвердить аккаунт, используйте одноразовый код ниже:",
+			"Код действителен {{.ExpiresIn}} мин. Если вы не запрашивали код, просто проигнорируйте это письмо.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateWelcome, "ru", Template{
+		Subject: "Добро пожаловать в Yelp, {{.Name}}!",
+		Title:   "Ваш аккаунт готов",
+		Paragraphs: []string{
+			"Здравствуйте, {{.Name}}! Ваш email подтверждён, аккаунт Yelp активирован.",
+			"Находите заведения рядом и делитесь впечатлениями в отзывах.",
+		},
+	})
+	register(TemplatePasswordReset, "ru", Template{
+		Subject: "Код для сброса пароля Yelp",
+		Title:   "Сброс пароля Yelp",
+		Paragraphs: []string{
+			"Мы получили запрос на сброс пароля вашего аккаунта Yelp. Чтобы задать новый пароль, используйте одноразовый код ниже:",
+			"Код действителен {{.ExpiresIn}} мин. Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateSecurityAlert, "ru", Template{
+		Subject: "Оповещение безопасности Yelp",
+		Title:   "Оповещение безопасности",
+		Paragraphs: []string{
+			`{{if eq .Event "password_changed"}}Пароль вашего аккаунта Yelp был изменён, все сеансы завершены.` +
+				`{{else if eq .Event "session_revoked"}}Токен входа вашего аккаунта Yelp был использован повторно, поэтому связанный с ним сеанс завершён.` +
+				`{{else}}В настройках безопасности вашего аккаунта Yelp произошли изменения.{{end}}`,
+			"Если это были вы, ничего делать не нужно. Если нет, немедленно сбросьте пароль.",
+		},
+	})
+
+	// Uzbek
+	register(TemplateOtp, "uz", Template{
+		Subject: "Yelp tasdiqlash kodi",
+		Title:   "Yelp'ga xush kelibsiz!",
+		Paragraphs: []string{
+			"Yelp'da ro'yxatdan o'tganingiz uchun rahmat! Ro'yxatdan o'tishni yakunlash va hisobingizni tasdiqlash uchun quyidagi bir martalik koddan foydalaning:",
+			"Kod {{.ExpiresIn}} daqiqa amal qiladi. Agar bu kodni so'ramagan bo'lsangiz, ushbu xatga e'tibor bermang.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateWelcome, "uz", Template{
+		Subject: "Yelp'ga xush kelibsiz, {{.Name}}!",
+		Title:   "Hisobingiz tayyor",
+		Paragraphs: []string{
+			"Salom, {{.Name}}! Emailingiz tasdiqlandi va Yelp hisobingiz faollashtirildi.",
+			"Yaqin atrofdagi bizneslarni kashf eting va sharhlar orqali taassurotlaringiz bilan bo'lishing.",
+		},
+	})
+	register(TemplatePasswordReset, "uz", Template{
+		Subject: "Yelp parolini tiklash kodi",
+		Title:   "Yelp parolini tiklash",
+		Paragraphs: []string{
+			"Yelp hisobingiz parolini tiklash so'rovini oldik. Yangi parol o'rnatish uchun quyidagi bir martalik koddan foydalaning:",
+			"Kod {{.ExpiresIn}} daqiqa amal qiladi. Agar parolni tiklashni so'ramagan bo'lsangiz, ushbu xatga e'tibor bermang.",
+		},
+		Code: "{{.Code}}",
+	})
+	register(TemplateSecurityAlert, "uz", Template{
+		Subject: "Yelp xavfsizlik ogohlantirishi",
+		Title:   "Xavfsizlik ogohlantirishi",
+		Paragraphs: []string{
+			`{{if eq .Event "password_changed"}}Yelp hisobingiz paroli o'zgartirildi va barcha seanslaringiz yakunlandi.` +
+				`{{else if eq .Event "session_revoked"}}Yelp hisobingizning kirish tokeni qayta ishlatildi, shuning uchun unga tegishli seans yakunlandi.` +
+				`{{else}}Yelp hisobingiz xavfsizlik sozlamalarida o'zgarish bo'ldi.{{end}}`,
+			"Agar bu siz bo'lsangiz, hech narsa qilish shart emas. Aks holda darhol parolingizni tiklang.",
+		},
+	})
+
+	return t
+}
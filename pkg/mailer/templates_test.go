@@ -0,0 +1,74 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplatesRender(t *testing.T) {
+	templates := NewTemplates("en")
+	for locale, subject := range map[string]string{"en": "Hello {{.Name}}", "uz": "Salom {{.Name}}", "uz-cyrl": "Салом {{.Name}}"} {
+		err := templates.Register("greeting", locale, Template{
+			Subject:    subject,
+			Title:      subject,
+			Paragraphs: []string{"<b>{{.Name}}</b>"},
+		})
+		if err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		locale      string
+		wantSubject string
+		wantErr     bool
+	}{
+		{name: "exact locale", template: "greeting", locale: "uz-Cyrl", wantSubject: "Салом <Aziz>"},
+		{name: "base language", template: "greeting", locale: "uz-Latn", wantSubject: "Salom <Aziz>"},
+		{name: "default locale", template: "greeting", locale: "fr", wantSubject: "Hello <Aziz>"},
+		{name: "no locale", template: "greeting", wantSubject: "Hello <Aziz>"},
+		{name: "unknown template", template: "farewell", locale: "en", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := templates.Render(tt.template, tt.locale, Data{Name: "<Aziz>"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if msg.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", msg.Subject, tt.wantSubject)
+			}
+			// fields are plain text, the HTML layout escapes them
+			if !strings.Contains(msg.HTML, "&lt;b&gt;&lt;Aziz&gt;&lt;/b&gt;") {
+				t.Errorf("HTML does not escape the paragraph:\n%s", msg.HTML)
+			}
+		})
+	}
+}
+
+func TestTemplatesRegister(t *testing.T) {
+	err := NewTemplates("en").Register("broken", "en", Template{Subject: "{{.Name"})
+	if err == nil {
+		t.Error("Register() accepted a template that does not parse")
+	}
+}
+
+func TestNewDefaultTemplates(t *testing.T) {
+	templates := NewDefaultTemplates("en")
+
+	for _, name := range []string{TemplateOtp, TemplateWelcome, TemplatePasswordReset, TemplateSecurityAlert} {
+		for _, locale := range []string{"en", "ru", "uz"} {
+			_, err := templates.Render(name, locale, Data{Name: "Aziz", Code: "123456", ExpiresIn: 5, Event: EventSessionRevoked})
+			if err != nil {
+				t.Errorf("Render(%q, %q) error = %v", name, locale, err)
+			}
+		}
+	}
+}
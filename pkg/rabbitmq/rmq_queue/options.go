@@ -0,0 +1,55 @@
+package rmqqueue
+
+import "time"
+
+// Option -.
+type Option func(*Worker)
+
+// Timeout limits the time a handler may spend on one job.
+func Timeout(timeout time.Duration) Option {
+	return func(w *Worker) {
+		w.timeout = timeout
+	}
+}
+
+// MaxAttempts is the number of tries before a job is moved to the dead-letter queue.
+func MaxAttempts(attempts int) Option {
+	return func(w *Worker) {
+		if attempts > 0 {
+			w.maxAttempts = attempts
+		}
+	}
+}
+
+// Backoff sets the delay before the first retry, it doubles on every following retry up to maxBackoff.
+func Backoff(backoff, maxBackoff time.Duration) Option {
+	return func(w *Worker) {
+		if backoff > 0 {
+			w.backoff = backoff
+		}
+		if maxBackoff >= w.backoff {
+			w.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// Prefetch -.
+func Prefetch(count int) Option {
+	return func(w *Worker) {
+		w.conn.Prefetch = count
+	}
+}
+
+// ConnWaitTime -.
+func ConnWaitTime(timeout time.Duration) Option {
+	return func(w *Worker) {
+		w.conn.WaitTime = timeout
+	}
+}
+
+// ConnAttempts -.
+func ConnAttempts(attempts int) Option {
+	return func(w *Worker) {
+		w.conn.Attempts = attempts
+	}
+}
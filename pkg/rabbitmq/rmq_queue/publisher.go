@@ -0,0 +1,106 @@
+// Package rmqqueue implements fire-and-forget jobs on top of rmqrpc.Connection:
+// a Publisher enqueues jobs into a durable queue and a Worker consumes them,
+// retrying failures with exponential backoff before parking them in a dead-letter queue.
+package rmqqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+
+	rmqrpc "github.com/abdulazizax/yelp/pkg/rabbitmq/rmq_rpc"
+)
+
+const (
+	_defaultWaitTime = 5 * time.Second
+	_defaultAttempts = 10
+)
+
+// Publisher -.
+type Publisher struct {
+	mu   sync.Mutex
+	conn *rmqrpc.Connection
+}
+
+// NewPublisher -.
+func NewPublisher(url, queue string) (*Publisher, error) {
+	p := &Publisher{
+		conn: newConnection(url, queue),
+	}
+	p.conn.PublishOnly = true
+
+	err := p.conn.AttemptConnect()
+	if err != nil {
+		return nil, fmt.Errorf("rmq_queue publisher - NewPublisher - p.conn.AttemptConnect: %w", err)
+	}
+
+	return p, nil
+}
+
+// Publish enqueues a persistent job, reconnecting once if the connection was lost.
+func (p *Publisher) Publish(_ context.Context, jobType string, body []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.publish(jobType, body)
+	if errors.Is(err, amqp.ErrClosed) {
+		err = p.conn.AttemptConnect()
+		if err != nil {
+			return fmt.Errorf("rmq_queue publisher - Publish - p.conn.AttemptConnect: %w", err)
+		}
+
+		err = p.publish(jobType, body)
+	}
+	if err != nil {
+		return fmt.Errorf("rmq_queue publisher - Publish - p.publish: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) publish(jobType string, body []byte) error {
+	return p.conn.Channel.Publish(p.conn.ConsumerExchange, "", false, false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    uuid.NewString(),
+			Timestamp:    time.Now(),
+			Type:         jobType,
+			Body:         body,
+		})
+}
+
+// Close -.
+func (p *Publisher) Close() error {
+	err := p.conn.Connection.Close()
+	if err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("rmq_queue publisher - Close - p.conn.Connection.Close: %w", err)
+	}
+
+	return nil
+}
+
+// newConnection uses a durable exchange and queue of the same name, so jobs survive until a worker takes them.
+func newConnection(url, queue string) *rmqrpc.Connection {
+	conn := rmqrpc.New(queue, rmqrpc.Config{
+		URL:      url,
+		WaitTime: _defaultWaitTime,
+		Attempts: _defaultAttempts,
+	})
+	conn.Queue = queue
+
+	return conn
+}
+
+func retryQueue(queue string) string {
+	return queue + ".retry"
+}
+
+func deadQueue(queue string) string {
+	return queue + ".dead"
+}
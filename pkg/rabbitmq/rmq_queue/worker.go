@@ -0,0 +1,272 @@
+package rmqqueue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/abdulazizax/yelp/pkg/logger"
+	rmqrpc "github.com/abdulazizax/yelp/pkg/rabbitmq/rmq_rpc"
+)
+
+const (
+	_defaultTimeout     = 30 * time.Second
+	_defaultMaxAttempts = 5
+	_defaultBackoff     = 2 * time.Second
+	_defaultMaxBackoff  = 5 * time.Minute
+	_defaultPrefetch    = 10
+
+	_headerAttempt = "x-attempt"
+	_headerError   = "x-error"
+)
+
+// Handler processes the body of one job, a returned error schedules a retry.
+type Handler func(ctx context.Context, body []byte) error
+
+// Worker -.
+type Worker struct {
+	conn   *rmqrpc.Connection
+	error  chan error
+	stop   chan struct{}
+	router map[string]Handler
+
+	timeout     time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+
+	logger logger.Interface
+}
+
+// NewWorker -.
+func NewWorker(url, queue string, router map[string]Handler, l logger.Interface, opts ...Option) (*Worker, error) {
+	w := &Worker{
+		conn:        newConnection(url, queue),
+		error:       make(chan error),
+		stop:        make(chan struct{}),
+		router:      router,
+		timeout:     _defaultTimeout,
+		maxAttempts: _defaultMaxAttempts,
+		backoff:     _defaultBackoff,
+		maxBackoff:  _defaultMaxBackoff,
+		logger:      l,
+	}
+	w.conn.Prefetch = _defaultPrefetch
+
+	// Custom options
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	err := w.connect()
+	if err != nil {
+		return nil, fmt.Errorf("rmq_queue worker - NewWorker - w.connect: %w", err)
+	}
+
+	go w.consumer()
+
+	return w, nil
+}
+
+// connect also declares the retry queue, whose expired jobs go back to the main exchange, and the dead-letter queue.
+func (w *Worker) connect() error {
+	err := w.conn.AttemptConnect()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.conn.Channel.QueueDeclare(retryQueue(w.conn.Queue), true, false, false, false,
+		amqp.Table{"x-dead-letter-exchange": w.conn.ConsumerExchange})
+	if err != nil {
+		return fmt.Errorf("w.conn.Channel.QueueDeclare: %w", err)
+	}
+
+	_, err = w.conn.Channel.QueueDeclare(deadQueue(w.conn.Queue), true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("w.conn.Channel.QueueDeclare: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Worker) consumer() {
+	for {
+		select {
+		case <-w.stop:
+			return
+		case d, opened := <-w.conn.Delivery:
+			if !opened {
+				w.reconnect()
+
+				return
+			}
+
+			w.serve(&d)
+		}
+	}
+}
+
+func (w *Worker) serve(d *amqp.Delivery) {
+	handler, ok := w.router[d.Type]
+	if !ok {
+		w.deadLetter(d, rmqrpc.ErrBadHandler)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	err := handler(ctx, d.Body)
+	cancel()
+
+	next, attempt := w.next(d, err)
+	switch next {
+	case actionAck:
+		_ = d.Ack(false) //nolint:errcheck // redelivered on failure anyway
+	case actionDeadLetter:
+		w.logger.Error(fmt.Errorf("rmq_queue worker - Worker - serve - handler: giving up after %d attempts: %w", attempt, err))
+		w.deadLetter(d, err)
+	case actionRetry:
+		w.logger.Warn("rmq_queue worker - Worker - serve - handler: attempt %d failed: %s", attempt, err)
+		w.retry(d, attempt, err)
+	}
+}
+
+type action int
+
+const (
+	actionAck action = iota
+	actionRetry
+	actionDeadLetter
+)
+
+// next decides what happens to a job after its handler returned err, along with the number of the attempt that ran
+func (w *Worker) next(d *amqp.Delivery, err error) (action, int) {
+	attempt := attempts(d) + 1
+
+	switch {
+	case err == nil:
+		return actionAck, attempt
+	case attempt >= w.maxAttempts:
+		return actionDeadLetter, attempt
+	default:
+		return actionRetry, attempt
+	}
+}
+
+// retry parks the job in the retry queue until its backoff expires. A job expires only once it reaches
+// the head of the queue, so a short backoff may wait behind a longer one, never more than maxBackoff.
+func (w *Worker) retry(d *amqp.Delivery, attempt int, cause error) {
+	headers := copyHeaders(d.Headers)
+	headers[_headerAttempt] = int32(attempt) //nolint:gosec // bounded by maxAttempts
+	headers[_headerError] = cause.Error()
+
+	w.republish(d, retryQueue(w.conn.Queue), headers, strconv.FormatInt(w.delay(attempt).Milliseconds(), 10))
+}
+
+func (w *Worker) deadLetter(d *amqp.Delivery, cause error) {
+	headers := copyHeaders(d.Headers)
+	headers[_headerError] = cause.Error()
+
+	w.republish(d, deadQueue(w.conn.Queue), headers, "")
+}
+
+// republish moves the job to another queue, it stays in the current one if that fails.
+func (w *Worker) republish(d *amqp.Delivery, queue string, headers amqp.Table, expiration string) {
+	err := w.conn.Channel.Publish("", queue, false, false,
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Timestamp:    d.Timestamp,
+			Type:         d.Type,
+			Expiration:   expiration,
+			Body:         d.Body,
+		})
+	if err != nil {
+		w.logger.Error(fmt.Errorf("rmq_queue worker - Worker - republish - w.conn.Channel.Publish: %w", err))
+		_ = d.Nack(false, true) //nolint:errcheck // redelivered on reconnect anyway
+
+		return
+	}
+
+	_ = d.Ack(false) //nolint:errcheck // redelivered on failure anyway
+}
+
+// delay is backoff doubled for every previous attempt, capped at maxBackoff.
+func (w *Worker) delay(attempt int) time.Duration {
+	delay := w.backoff
+	for i := 1; i < attempt && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > w.maxBackoff {
+		return w.maxBackoff
+	}
+
+	return delay
+}
+
+func attempts(d *amqp.Delivery) int {
+	switch v := d.Headers[_headerAttempt].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+func copyHeaders(headers amqp.Table) amqp.Table {
+	copied := make(amqp.Table, len(headers)+2)
+	for k, v := range headers {
+		copied[k] = v
+	}
+
+	return copied
+}
+
+func (w *Worker) reconnect() {
+	close(w.stop)
+
+	err := w.connect()
+	if err != nil {
+		w.error <- err
+		close(w.error)
+
+		return
+	}
+
+	w.stop = make(chan struct{})
+
+	go w.consumer()
+}
+
+// Notify -.
+func (w *Worker) Notify() <-chan error {
+	return w.error
+}
+
+// Shutdown -.
+func (w *Worker) Shutdown() error {
+	select {
+	case <-w.error:
+		return nil
+	default:
+	}
+
+	close(w.stop)
+
+	err := w.conn.Connection.Close()
+	if err != nil {
+		return fmt.Errorf("rmq_queue worker - Worker - Shutdown - w.conn.Connection.Close: %w", err)
+	}
+
+	return nil
+}
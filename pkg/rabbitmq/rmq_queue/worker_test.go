@@ -0,0 +1,77 @@
+package rmqqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+func TestWorkerNext(t *testing.T) {
+	errFailed := errors.New("smtp: connection refused")
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		headers     amqp.Table
+		err         error
+		want        action
+		wantAttempt int
+	}{
+		{name: "first attempt succeeds", maxAttempts: 5, want: actionAck, wantAttempt: 1},
+		{name: "retried attempt succeeds", maxAttempts: 5, headers: amqp.Table{_headerAttempt: int32(4)}, want: actionAck, wantAttempt: 5},
+		{name: "first attempt fails", maxAttempts: 5, err: errFailed, want: actionRetry, wantAttempt: 1},
+		{name: "attempt before the last fails", maxAttempts: 5, headers: amqp.Table{_headerAttempt: int32(3)}, err: errFailed, want: actionRetry, wantAttempt: 4},
+		{name: "last attempt fails", maxAttempts: 5, headers: amqp.Table{_headerAttempt: int32(4)}, err: errFailed, want: actionDeadLetter, wantAttempt: 5},
+		{name: "single attempt", maxAttempts: 1, err: errFailed, want: actionDeadLetter, wantAttempt: 1},
+		{name: "int64 header", maxAttempts: 5, headers: amqp.Table{_headerAttempt: int64(2)}, err: errFailed, want: actionRetry, wantAttempt: 3},
+		{name: "malformed header counts as none", maxAttempts: 5, headers: amqp.Table{_headerAttempt: "4"}, err: errFailed, want: actionRetry, wantAttempt: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Worker{maxAttempts: tt.maxAttempts}
+
+			got, attempt := w.next(&amqp.Delivery{Headers: tt.headers}, tt.err)
+			if got != tt.want || attempt != tt.wantAttempt {
+				t.Errorf("next() = %v, %d, want %v, %d", got, attempt, tt.want, tt.wantAttempt)
+			}
+		})
+	}
+}
+
+func TestWorkerDelay(t *testing.T) {
+	w := &Worker{backoff: 2 * time.Second, maxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 2 * time.Second},
+		{attempt: 2, want: 4 * time.Second},
+		{attempt: 3, want: 8 * time.Second},
+		{attempt: 4, want: 10 * time.Second},
+		{attempt: 100, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := w.delay(tt.attempt); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestCopyHeaders(t *testing.T) {
+	headers := amqp.Table{_headerAttempt: int32(1), "x-trace": "abc"}
+
+	copied := copyHeaders(headers)
+	copied[_headerAttempt] = int32(2)
+
+	if headers[_headerAttempt] != int32(1) {
+		t.Error("copyHeaders() shares the map with the delivery")
+	}
+	if copied["x-trace"] != "abc" {
+		t.Errorf("copyHeaders() dropped x-trace: %v", copied)
+	}
+}
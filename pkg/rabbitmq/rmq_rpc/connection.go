@@ -18,6 +18,13 @@ type Config struct {
 // Connection -.
 type Connection struct {
 	ConsumerExchange string
+	// Queue, when set, is a durable queue shared by all consumers instead of an exclusive one per connection.
+	Queue     string
+	QueueArgs amqp.Table
+	// PublishOnly connections declare the exchange and queue but do not consume from it.
+	PublishOnly bool
+	// Prefetch limits unacknowledged deliveries per consumer, 0 means no limit.
+	Prefetch int
 	Config
 	Connection *amqp.Connection
 	Channel    *amqp.Channel
@@ -66,10 +73,12 @@ func (c *Connection) connect() error {
 		return fmt.Errorf("c.Connection.Channel: %w", err)
 	}
 
+	durable := c.Queue != ""
+
 	err = c.Channel.ExchangeDeclare(
 		c.ConsumerExchange,
 		"fanout",
-		false,
+		durable,
 		false,
 		false,
 		false,
@@ -80,12 +89,12 @@ func (c *Connection) connect() error {
 	}
 
 	queue, err := c.Channel.QueueDeclare(
-		"",
+		c.Queue,
+		durable,
 		false,
+		!durable,
 		false,
-		true,
-		false,
-		nil,
+		c.QueueArgs,
 	)
 	if err != nil {
 		return fmt.Errorf("c.Channel.QueueDeclare: %w", err)
@@ -102,6 +111,17 @@ func (c *Connection) connect() error {
 		return fmt.Errorf("c.Channel.QueueBind: %w", err)
 	}
 
+	if c.PublishOnly {
+		return nil
+	}
+
+	if c.Prefetch > 0 {
+		err = c.Channel.Qos(c.Prefetch, 0, false)
+		if err != nil {
+			return fmt.Errorf("c.Channel.Qos: %w", err)
+		}
+	}
+
 	c.Delivery, err = c.Channel.Consume(
 		queue.Name,
 		"",
@@ -0,0 +1,85 @@
+package screening
+
+import (
+	"context"
+	"time"
+)
+
+// Posted is content the user published earlier.
+type Posted struct {
+	ID   string
+	Text string
+}
+
+// History looks up what a user posted within the window before now.
+type History interface {
+	Recent(ctx context.Context, userID string, window time.Duration) ([]Posted, error)
+}
+
+// Duplicate rejects text that repeats another recent post of the same user.
+type Duplicate struct {
+	history  History
+	window   time.Duration
+	minWords int
+}
+
+var _ Checker = (*Duplicate)(nil)
+
+// NewDuplicate compares with the posts of the last window, ignoring texts shorter than minWords words.
+func NewDuplicate(history History, window time.Duration, minWords int) *Duplicate {
+	return &Duplicate{history: history, window: window, minWords: minWords}
+}
+
+// Check -.
+func (d *Duplicate) Check(ctx context.Context, content Content) (Result, error) {
+	text := words(content.Text)
+	if len(text) < d.minWords {
+		return Result{Verdict: Allow}, nil
+	}
+
+	posts, err := d.history.Recent(ctx, content.UserID, d.window)
+	if err != nil {
+		return Result{}, err
+	}
+
+	normalized := normalize(content.Text)
+	for _, post := range posts {
+		if post.ID != content.ID && normalize(post.Text) == normalized {
+			return Result{Verdict: Reject, Reason: ReasonDuplicate}, nil
+		}
+	}
+
+	return Result{Verdict: Allow}, nil
+}
+
+// Rate rejects new posts once the user posted limit times within the window. Edits are not counted.
+type Rate struct {
+	history History
+	window  time.Duration
+	limit   int
+}
+
+var _ Checker = (*Rate)(nil)
+
+// NewRate -.
+func NewRate(history History, window time.Duration, limit int) *Rate {
+	return &Rate{history: history, window: window, limit: limit}
+}
+
+// Check -.
+func (r *Rate) Check(ctx context.Context, content Content) (Result, error) {
+	if content.ID != "" || r.limit <= 0 {
+		return Result{Verdict: Allow}, nil
+	}
+
+	posts, err := r.history.Recent(ctx, content.UserID, r.window)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(posts) >= r.limit {
+		return Result{Verdict: Reject, Reason: ReasonRateLimited}, nil
+	}
+
+	return Result{Verdict: Allow}, nil
+}
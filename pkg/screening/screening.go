@@ -0,0 +1,92 @@
+// Package screening checks user written text for spam and abuse before it is published.
+package screening
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// Verdict -.
+type Verdict int
+
+const (
+	// Allow publishes the content.
+	Allow Verdict = iota
+	// Hold keeps the content for a moderator.
+	Hold
+	// Reject refuses the content.
+	Reject
+)
+
+// Reason codes reported with a Hold or Reject verdict.
+const (
+	ReasonBlockedWord = "blocked_word"
+	ReasonFlaggedWord = "flagged_word"
+	ReasonLink        = "link"
+	ReasonPhoneNumber = "phone_number"
+	ReasonDuplicate   = "duplicate_text"
+	ReasonRateLimited = "rate_limited"
+)
+
+// Content is the text to screen and who wrote it.
+type Content struct {
+	UserID string
+	// ID of the content when it is edited, empty for new content.
+	ID   string
+	Text string
+}
+
+// Result -.
+type Result struct {
+	Verdict Verdict
+	Reason  string
+}
+
+// Checker is one step of the pipeline.
+type Checker interface {
+	Check(ctx context.Context, content Content) (Result, error)
+}
+
+// Pipeline runs its checkers in order, stopping at the first rejection. The first hold wins otherwise.
+type Pipeline struct {
+	checkers []Checker
+}
+
+// New -.
+func New(checkers ...Checker) *Pipeline {
+	return &Pipeline{checkers: checkers}
+}
+
+// Screen -.
+func (p *Pipeline) Screen(ctx context.Context, content Content) (Result, error) {
+	result := Result{Verdict: Allow}
+
+	for _, checker := range p.checkers {
+		r, err := checker.Check(ctx, content)
+		if err != nil {
+			return Result{}, err
+		}
+
+		if r.Verdict == Reject {
+			return r, nil
+		}
+
+		if r.Verdict == Hold && result.Verdict == Allow {
+			result = r
+		}
+	}
+
+	return result, nil
+}
+
+// normalize lowercases the text and reduces it to words separated by single spaces
+func normalize(text string) string {
+	return strings.Join(words(text), " ")
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
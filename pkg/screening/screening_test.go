@@ -0,0 +1,207 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeHistory returns the same posts for every user and remembers the window it was asked for
+type fakeHistory struct {
+	posts  []Posted
+	err    error
+	window time.Duration
+}
+
+func (f *fakeHistory) Recent(_ context.Context, _ string, window time.Duration) ([]Posted, error) {
+	f.window = window
+	return f.posts, f.err
+}
+
+// fixed always answers with the same result
+type fixed Result
+
+func (f fixed) Check(context.Context, Content) (Result, error) {
+	return Result(f), nil
+}
+
+type failing struct{}
+
+func (failing) Check(context.Context, Content) (Result, error) {
+	return Result{}, errors.New("history unavailable")
+}
+
+func TestWordList(t *testing.T) {
+	list := NewWordList([]string{" Scam ", "", "FAKE"}, Reject, ReasonBlockedWord)
+
+	tests := []struct {
+		name string
+		text string
+		want Verdict
+	}{
+		{name: "clean text", text: "Great coffee and friendly staff", want: Allow},
+		{name: "listed word", text: "This place is a scam", want: Reject},
+		{name: "case insensitive", text: "Total SCAM!", want: Reject},
+		{name: "surrounded by punctuation", text: "so...fake...", want: Reject},
+		{name: "part of a longer word", text: "The scampi was great", want: Allow},
+		{name: "empty text", text: "", want: Allow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := list.Check(context.Background(), Content{Text: tt.text})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got.Verdict != tt.want {
+				t.Errorf("Check(%q) = %v, want %v", tt.text, got.Verdict, tt.want)
+			}
+			if got.Verdict == Reject && got.Reason != ReasonBlockedWord {
+				t.Errorf("Check(%q) reason = %q, want %q", tt.text, got.Reason, ReasonBlockedWord)
+			}
+		})
+	}
+}
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker *Pattern
+		text    string
+		want    Verdict
+	}{
+		{name: "http link", checker: NewLinks(Hold), text: "see http://example.org/menu", want: Hold},
+		{name: "https link", checker: NewLinks(Hold), text: "HTTPS://shop.example.com", want: Hold},
+		{name: "www link", checker: NewLinks(Hold), text: "visit www.cheap-deals", want: Hold},
+		{name: "bare domain", checker: NewLinks(Hold), text: "order at pizza-now.uz today", want: Hold},
+		{name: "sentence end", checker: NewLinks(Hold), text: "Good food.Nice staff", want: Allow},
+		{name: "unknown top level domain", checker: NewLinks(Hold), text: "file.txt was attached", want: Allow},
+		{name: "international phone", checker: NewPhoneNumbers(Hold), text: "call +998 (90) 123-45-67", want: Hold},
+		{name: "plain phone", checker: NewPhoneNumbers(Hold), text: "call 901234567", want: Hold},
+		{name: "dotted phone", checker: NewPhoneNumbers(Hold), text: "90.123.45.67 anytime", want: Hold},
+		{name: "short number", checker: NewPhoneNumbers(Hold), text: "waited 45 minutes for 2 pizzas", want: Allow},
+		{name: "year and price", checker: NewPhoneNumbers(Hold), text: "since 2015, about 30000 sum", want: Allow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.checker.Check(context.Background(), Content{Text: tt.text})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got.Verdict != tt.want {
+				t.Errorf("Check(%q) = %v, want %v", tt.text, got.Verdict, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	posts := []Posted{
+		{ID: "1", Text: "Best burgers in town, the fries are amazing too!"},
+		{ID: "2", Text: "ok"},
+	}
+
+	tests := []struct {
+		name    string
+		content Content
+		err     error
+		want    Verdict
+		wantErr bool
+	}{
+		{name: "same text", content: Content{Text: "Best burgers in town, the fries are amazing too!"}, want: Reject},
+		{name: "same words", content: Content{Text: "best BURGERS in town -- the fries are amazing too"}, want: Reject},
+		{name: "different text", content: Content{Text: "Best burgers in town, the shakes are amazing too!"}, want: Allow},
+		{name: "editing the same post", content: Content{ID: "1", Text: "Best burgers in town, the fries are amazing too!"}, want: Allow},
+		{name: "short text", content: Content{Text: "ok"}, want: Allow},
+		{name: "history fails", content: Content{Text: "one two three four five six"}, err: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakeHistory{posts: posts, err: tt.err}
+
+			got, err := NewDuplicate(history, 720*time.Hour, 5).Check(context.Background(), tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Verdict != tt.want {
+				t.Errorf("Check() = %v, want %v", got.Verdict, tt.want)
+			}
+			if got.Verdict == Reject && got.Reason != ReasonDuplicate {
+				t.Errorf("Check() reason = %q, want %q", got.Reason, ReasonDuplicate)
+			}
+			if len(words(tt.content.Text)) >= 5 && history.window != 720*time.Hour {
+				t.Errorf("Recent() window = %v, want %v", history.window, 720*time.Hour)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	three := []Posted{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	tests := []struct {
+		name    string
+		limit   int
+		posts   []Posted
+		content Content
+		want    Verdict
+	}{
+		{name: "under the limit", limit: 5, posts: three, content: Content{UserID: "u"}, want: Allow},
+		{name: "at the limit", limit: 3, posts: three, content: Content{UserID: "u"}, want: Reject},
+		{name: "edits are not counted", limit: 3, posts: three, content: Content{UserID: "u", ID: "1"}, want: Allow},
+		{name: "no limit", limit: 0, posts: three, content: Content{UserID: "u"}, want: Allow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakeHistory{posts: tt.posts}
+
+			got, err := NewRate(history, time.Hour, tt.limit).Check(context.Background(), tt.content)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got.Verdict != tt.want {
+				t.Errorf("Check() = %v, want %v", got.Verdict, tt.want)
+			}
+			if got.Verdict == Reject && got.Reason != ReasonRateLimited {
+				t.Errorf("Check() reason = %q, want %q", got.Reason, ReasonRateLimited)
+			}
+		})
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	allow := fixed{Verdict: Allow}
+	link := fixed{Verdict: Hold, Reason: ReasonLink}
+	phone := fixed{Verdict: Hold, Reason: ReasonPhoneNumber}
+	blocked := fixed{Verdict: Reject, Reason: ReasonBlockedWord}
+
+	tests := []struct {
+		name     string
+		checkers []Checker
+		want     Result
+		wantErr  bool
+	}{
+		{name: "no checkers", want: Result{Verdict: Allow}},
+		{name: "all allow", checkers: []Checker{allow, allow}, want: Result{Verdict: Allow}},
+		{name: "first hold wins", checkers: []Checker{allow, link, phone}, want: Result{Verdict: Hold, Reason: ReasonLink}},
+		{name: "reject after hold", checkers: []Checker{link, blocked}, want: Result{Verdict: Reject, Reason: ReasonBlockedWord}},
+		{name: "reject stops the pipeline", checkers: []Checker{blocked, failing{}}, want: Result{Verdict: Reject, Reason: ReasonBlockedWord}},
+		{name: "checker error", checkers: []Checker{allow, failing{}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.checkers...).Screen(context.Background(), Content{Text: "text"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Screen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Screen() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
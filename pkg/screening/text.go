@@ -0,0 +1,78 @@
+package screening
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// WordList matches whole words of the text, case insensitive.
+type WordList struct {
+	words   map[string]bool
+	verdict Verdict
+	reason  string
+}
+
+var _ Checker = (*WordList)(nil)
+
+// NewWordList -.
+func NewWordList(words []string, verdict Verdict, reason string) *WordList {
+	w := &WordList{
+		words:   make(map[string]bool, len(words)),
+		verdict: verdict,
+		reason:  reason,
+	}
+
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			w.words[word] = true
+		}
+	}
+
+	return w
+}
+
+// Check -.
+func (w *WordList) Check(_ context.Context, content Content) (Result, error) {
+	for _, word := range words(content.Text) {
+		if w.words[word] {
+			return Result{Verdict: w.verdict, Reason: w.reason}, nil
+		}
+	}
+
+	return Result{Verdict: Allow}, nil
+}
+
+// Pattern matches a regular expression anywhere in the text.
+type Pattern struct {
+	re      *regexp.Regexp
+	verdict Verdict
+	reason  string
+}
+
+var _ Checker = (*Pattern)(nil)
+
+var (
+	linkRe  = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|info|biz|xyz|io|me|ru|uz)\b`)
+	phoneRe = regexp.MustCompile(`\+?\d(?:[\s().-]*\d){8,}`)
+)
+
+// NewLinks catches URLs and bare domain names.
+func NewLinks(verdict Verdict) *Pattern {
+	return &Pattern{re: linkRe, verdict: verdict, reason: ReasonLink}
+}
+
+// NewPhoneNumbers catches runs of 9 or more digits, allowing the usual separators between them.
+func NewPhoneNumbers(verdict Verdict) *Pattern {
+	return &Pattern{re: phoneRe, verdict: verdict, reason: ReasonPhoneNumber}
+}
+
+// Check -.
+func (p *Pattern) Check(_ context.Context, content Content) (Result, error) {
+	if p.re.MatchString(content.Text) {
+		return Result{Verdict: p.verdict, Reason: p.reason}, nil
+	}
+
+	return Result{Verdict: Allow}, nil
+}
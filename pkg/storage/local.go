@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Local stores files on the local filesystem.
+type Local struct {
+	dir     string
+	baseURL string
+}
+
+var _ Storage = (*Local)(nil)
+
+// NewLocal -.
+func NewLocal(dir, baseURL string) (*Local, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewLocal - os.MkdirAll: %w", err)
+	}
+
+	return &Local{
+		dir:     dir,
+		baseURL: baseURL,
+	}, nil
+}
+
+// Put -.
+func (l *Local) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(l.dir, filepath.FromSlash(key))
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", fmt.Errorf("storage - Local - Put - os.MkdirAll: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("storage - Local - Put - os.Create: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, body)
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("storage - Local - Put - io.Copy: %w", err)
+	}
+
+	return joinURL(l.baseURL, key), nil
+}
+
+// Delete -.
+func (l *Local) Delete(_ context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(l.dir, filepath.FromSlash(key)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("storage - Local - Delete - os.Remove: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config -.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	// BaseURL overrides the URL files are served from, e.g. a CDN in front of the bucket.
+	// Without it files are served straight from the bucket, which is then made readable by anyone.
+	BaseURL string
+}
+
+// S3 stores files in an S3 compatible object storage (AWS S3, MinIO, ...).
+type S3 struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+var _ Storage = (*S3)(nil)
+
+// NewS3 -.
+func NewS3(ctx context.Context, cfg S3Config) (*S3, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewS3 - minio.New: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewS3 - client.BucketExists: %w", err)
+	}
+
+	if !exists {
+		err = client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region})
+		if err != nil {
+			return nil, fmt.Errorf("storage - NewS3 - client.MakeBucket: %w", err)
+		}
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = client.EndpointURL().String() + "/" + cfg.Bucket
+
+		err = client.SetBucketPolicy(ctx, cfg.Bucket, publicReadPolicy(cfg.Bucket))
+		if err != nil {
+			return nil, fmt.Errorf("storage - NewS3 - client.SetBucketPolicy: %w", err)
+		}
+	}
+
+	return &S3{
+		client:  client,
+		bucket:  cfg.Bucket,
+		baseURL: baseURL,
+	}, nil
+}
+
+// Put -.
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("storage - S3 - Put - client.PutObject: %w", err)
+	}
+
+	return joinURL(s.baseURL, key), nil
+}
+
+// Delete -.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("storage - S3 - Delete - client.RemoveObject: %w", err)
+	}
+
+	return nil
+}
+
+// publicReadPolicy lets anyone download the objects of bucket, but not list or change them
+func publicReadPolicy(bucket string) string {
+	return `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},` +
+		`"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::` + bucket + `/*"]}]}`
+}
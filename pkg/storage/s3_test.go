@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 answers the few S3 calls the driver makes and records the objects it was sent
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+	policy  string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodHead && strings.TrimSuffix(r.URL.Path, "/") == "/yelp":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut && r.URL.Query().Has("policy"):
+		body, _ := io.ReadAll(r.Body)
+		f.policy = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func newFakeS3(t *testing.T, baseURL string) (*S3, *fakeS3, string) {
+	t.Helper()
+
+	fake := &fakeS3{objects: map[string]string{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	s3, err := NewS3(context.Background(), S3Config{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    "yelp",
+		AccessKey: "key",
+		SecretKey: "secret",
+		BaseURL:   baseURL,
+	})
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+
+	return s3, fake, server.URL
+}
+
+func TestS3Put(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		key     string
+		wantURL func(endpoint string) string
+		wantKey string
+		wantErr error
+	}{
+		{
+			name:    "bucket URL",
+			key:     "reviews/1/photo.jpg",
+			wantURL: func(endpoint string) string { return endpoint + "/yelp/reviews/1/photo.jpg" },
+			wantKey: "/yelp/reviews/1/photo.jpg",
+		},
+		{
+			name:    "leading slash is dropped",
+			key:     "/businesses/2/logo.png",
+			wantURL: func(endpoint string) string { return endpoint + "/yelp/businesses/2/logo.png" },
+			wantKey: "/yelp/businesses/2/logo.png",
+		},
+		{
+			name:    "CDN base URL",
+			baseURL: "https://cdn.example.com/media/",
+			key:     "reviews/1/photo.jpg",
+			wantURL: func(string) string { return "https://cdn.example.com/media/reviews/1/photo.jpg" },
+			wantKey: "/yelp/reviews/1/photo.jpg",
+		},
+		{name: "parent directory", key: "reviews/../../etc/passwd", wantErr: ErrInvalidKey},
+		{name: "empty key", key: "/", wantErr: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s3, fake, endpoint := newFakeS3(t, tt.baseURL)
+
+			url, err := s3.Put(context.Background(), tt.key, strings.NewReader("data"), 4, "image/jpeg")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Put() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(fake.objects) != 0 {
+					t.Errorf("Put() stored %v for an invalid key", fake.objects)
+				}
+				return
+			}
+
+			if want := tt.wantURL(endpoint); url != want {
+				t.Errorf("Put() url = %q, want %q", url, want)
+			}
+			// over plain HTTP the body is sent in signed chunks around the data
+			if got, ok := fake.objects[tt.wantKey]; !ok || !strings.Contains(got, "data") {
+				t.Errorf("object %s = %q, want it to hold %q", tt.wantKey, got, "data")
+			}
+			if got := fake.types[tt.wantKey]; got != "image/jpeg" {
+				t.Errorf("object %s content type = %q, want image/jpeg", tt.wantKey, got)
+			}
+		})
+	}
+}
+
+func TestS3Delete(t *testing.T) {
+	s3, fake, _ := newFakeS3(t, "")
+
+	_, err := s3.Put(context.Background(), "reviews/1/photo.jpg", strings.NewReader("data"), 4, "image/jpeg")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	err = s3.Delete(context.Background(), "reviews/1/photo.jpg")
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := fake.objects["/yelp/reviews/1/photo.jpg"]; ok {
+		t.Error("Delete() left the object in the bucket")
+	}
+
+	if err := s3.Delete(context.Background(), "../secret"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Delete() error = %v, want %v", err, ErrInvalidKey)
+	}
+}
+
+func TestNewS3Policy(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseURL    string
+		wantPublic bool
+	}{
+		{name: "served from the bucket", wantPublic: true},
+		{name: "served from a CDN", baseURL: "https://cdn.example.com/media"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, fake, _ := newFakeS3(t, tt.baseURL)
+
+			if !tt.wantPublic {
+				if fake.policy != "" {
+					t.Errorf("NewS3() set policy %s, want none", fake.policy)
+				}
+				return
+			}
+
+			var policy struct {
+				Statement []struct {
+					Effect   string
+					Action   []string
+					Resource []string
+				}
+			}
+			if err := json.Unmarshal([]byte(fake.policy), &policy); err != nil {
+				t.Fatalf("policy %q is not JSON: %v", fake.policy, err)
+			}
+			if len(policy.Statement) != 1 {
+				t.Fatalf("policy has %d statements, want 1", len(policy.Statement))
+			}
+
+			statement := policy.Statement[0]
+			if statement.Effect != "Allow" || len(statement.Action) != 1 || statement.Action[0] != "s3:GetObject" {
+				t.Errorf("policy statement = %+v, want only s3:GetObject allowed", statement)
+			}
+			if len(statement.Resource) != 1 || statement.Resource[0] != "arn:aws:s3:::yelp/*" {
+				t.Errorf("policy resource = %v, want the objects of the bucket", statement.Resource)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package storage implements file storage drivers.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrInvalidKey -.
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// Storage -.
+type Storage interface {
+	// Put stores the body under key and returns the public URL of the stored file.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+	// Delete removes the file stored under key.
+	Delete(ctx context.Context, key string) error
+}
+
+func cleanKey(key string) (string, error) {
+	key = strings.TrimLeft(key, "/")
+	if key == "" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+func joinURL(baseURL, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + key
+}